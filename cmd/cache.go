@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cache of files known to be well formatted",
+	Run:   setupCmdFunc(manageCache),
+}
+
+func manageCache(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if err := cmd.Help(); err != nil {
+		msgHandler.errorFatal(err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+}
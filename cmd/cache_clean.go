@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove all entries from the cache",
+	Run:   setupCmdFunc(cleanCache),
+}
+
+func cleanCache(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	dir := viper.GetString("cache.dir")
+	if dir == "" {
+		msgHandler.errorFatalStr("no cache folder defined")
+	}
+
+	if err := ghokin.CleanCache(dir); err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success(`cache "%s" cleaned`, dir)
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheCleanCmd)
+}
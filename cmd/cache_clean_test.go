@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanCache(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin-cache/ab", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin-cache/ab/abcd", []byte{}, 0o777))
+	viper.Set("cache.dir", "/tmp/ghokin-cache")
+	defer viper.Reset()
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		cleanCache(msgHandler, &cobra.Command{}, []string{})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, `cache "/tmp/ghokin-cache" cleaned`+"\n", stdout.String())
+	_, err := os.Stat("/tmp/ghokin-cache")
+	assert.True(t, os.IsNotExist(err))
+}
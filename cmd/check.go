@@ -5,18 +5,27 @@ import (
 )
 
 var checkCmd = &cobra.Command{
-	Use:   "check [file or folder path]",
-	Short: "Check a file/folder is well formatted",
-	Long:  "Check a file/folder is well formatted, otherwise it exit with an error code and the list of file badly formatted",
+	Use:   "check [file or folder path]...",
+	Short: "Check files/folders are well formatted",
+	Long:  "Check files/folders are well formatted, otherwise it exit with an error code and the list of file badly formatted",
 	Run:   setupCmdFunc(check),
 }
 
 func check(msgHandler messageHandler, cmd *cobra.Command, args []string) {
-	if len(args) != 1 {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
 		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
 	}
 
-	if errs := getFileManager().Check(args[0], extensions); len(errs) > 0 {
+	fileManager, err := getFileManager()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if errs := fileManager.Check(paths, extensions).Errors(); len(errs) > 0 {
 		for _, e := range errs {
 			msgHandler.error(e)
 		}
@@ -24,10 +33,15 @@ func check(msgHandler messageHandler, cmd *cobra.Command, args []string) {
 		msgHandler.exit(1)
 	}
 
-	msgHandler.success(`"%s" is well formatted`, args[0])
+	msgHandler.success("%s", describePaths(paths, "is well formatted", "are well formatted"))
 }
 
 func init() {
 	checkCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	checkCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to check from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	checkCmd.Flags().BoolVar(&checkIdempotency, "check-idempotent", false, "Format every file twice and fail if the second pass changes the output")
+	checkCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	checkCmd.Flags().IntVar(&aliasJobs, "alias-jobs", 0, "Maximum number of alias commands running at the same time, 0 means no limit")
+	checkCmd.Flags().BoolVar(&noCache, "no-cache", false, "Don't use the cache to skip files known to be well formatted")
 	rootCmd.AddCommand(checkCmd)
 }
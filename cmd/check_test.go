@@ -115,3 +115,50 @@ func TestCheckErrors(t *testing.T) {
 		stdout.Reset()
 	}
 }
+
+func TestCheckSeveralPaths(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("Feature: Test\n  Scenario: Scenario1\n    Given a test\n"), 0o755))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file2.feature", []byte("Feature: Test\n  Scenario: Scenario2\n    Given a test\n"), 0o755))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file3.feature", []byte("Feature: Test\n  Scenario: Scenario3\n    Given a test\n"), 0o755))
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		cmd := &cobra.Command{}
+		cmd.SetIn(bytes.NewBufferString("/tmp/ghokin/file2.feature\x00/tmp/ghokin/file3.feature\x00/tmp/ghokin/file1.feature\x00"))
+		filesFrom = "-"
+		defer func() { filesFrom = "" }()
+
+		check(msgHandler, cmd, []string{"/tmp/ghokin/file1.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with errors (exit 0)")
+	assert.EqualValues(t, `"/tmp/ghokin/file1.feature", "/tmp/ghokin/file2.feature", "/tmp/ghokin/file3.feature" are well formatted`+"\n", stdout.String())
+}
@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
 	"github.com/antham/ghokin/v3/ghokin"
 
 	"github.com/spf13/cobra"
@@ -14,16 +21,199 @@ func setupCmdFunc(f func(messageHandler, *cobra.Command, []string)) func(*cobra.
 	}
 }
 
-func getFileManager() ghokin.FileManager {
+func getFileManager(opts ...ghokin.Option) (ghokin.FileManager, error) {
+	options, err := getFormatOptions()
+	if err != nil {
+		return ghokin.FileManager{}, err
+	}
+	options = append(options, ghokin.WithJobs(getIntFlagOrConfig(jobs, "jobs")))
+	if viper.GetBool("cache.enabled") && !noCache {
+		options = append(options, ghokin.WithCache(viper.GetString("cache.dir"), getCacheKey()))
+	}
+	options = append(options, opts...)
 	return ghokin.NewFileManager(
 		viper.GetInt("indent"),
 		viper.GetStringMapString("aliases"),
-	)
+		options...,
+	), nil
+}
+
+// getFormatOptions returns options shared by all commands formatting contents
+func getFormatOptions() ([]ghokin.Option, error) {
+	options := []ghokin.Option{
+		ghokin.WithIdempotencyCheck(checkIdempotency),
+		ghokin.WithAliasJobs(getIntFlagOrConfig(aliasJobs, "aliasJobs")),
+	}
+	if style := viper.GetString("stepKeywords"); style != "" {
+		stepKeywordStyle, err := ghokin.ParseStepKeywordStyle(style)
+		if err != nil {
+			return []ghokin.Option{}, err
+		}
+		options = append(options, ghokin.WithStepKeywordStyle(stepKeywordStyle))
+	}
+	tagStyle, err := getTagStyle()
+	if err != nil {
+		return []ghokin.Option{}, err
+	}
+	keywords, err := getKeywords()
+	if err != nil {
+		return []ghokin.Option{}, err
+	}
+	return append(
+		options,
+		ghokin.WithTagStyle(tagStyle),
+		ghokin.WithKeywords(keywords),
+		ghokin.WithBlankLines(getBlankLines()),
+		ghokin.WithMaxLineLength(viper.GetInt("description.maxLineLength")),
+	), nil
+}
+
+func getKeywords() (ghokin.Keywords, error) {
+	config := map[string]map[string]string{}
+	for language, value := range viper.GetStringMap("keywords") {
+		kinds, ok := value.(map[string]interface{})
+		if !ok {
+			return ghokin.Keywords{}, fmt.Errorf(`check the keywords of the language "%s" are a map`, language)
+		}
+		config[language] = map[string]string{}
+		for kind, keyword := range kinds {
+			config[language][kind] = fmt.Sprint(keyword)
+		}
+	}
+	return ghokin.ParseKeywords(config)
+}
+
+func getBlankLines() ghokin.BlankLines {
+	count := func(key string) int {
+		if !viper.IsSet(key) {
+			return ghokin.BlankLinesPreserve
+		}
+		return viper.GetInt(key)
+	}
+	return ghokin.BlankLines{
+		FeatureDescription: count("blankLines.featureDescription"),
+		AfterBackground:    count("blankLines.afterBackground"),
+		Scenario:           count("blankLines.scenario"),
+		Examples:           count("blankLines.examples"),
+		Collapse:           viper.GetBool("blankLines.collapse"),
+		Trim:               viper.GetBool("blankLines.trim"),
+	}
+}
+
+func getTagStyle() (ghokin.TagStyle, error) {
+	style := ghokin.TagStyle{
+		Priority: viper.GetStringSlice("tags.priority"),
+		Dedupe:   viper.GetBool("tags.dedupe"),
+		Width:    viper.GetInt("tags.width"),
+	}
+	var err error
+	if sort := viper.GetString("tags.sort"); sort != "" {
+		if style.Sort, err = ghokin.ParseTagSort(sort); err != nil {
+			return ghokin.TagStyle{}, err
+		}
+	}
+	if lines := viper.GetString("tags.lines"); lines != "" {
+		if style.Lines, err = ghokin.ParseTagLines(lines); err != nil {
+			return ghokin.TagStyle{}, err
+		}
+	}
+	return style, nil
+}
+
+// formatSettings lists config keys changing the output of the formatter
+var formatSettings = []string{"indent", "aliases", "stepKeywords", "tags", "blankLines", "description", "keywords"}
+
+// getCacheKey identifies the version and the effective configuration
+// used to format files, a change in any of them invalidates the cache
+func getCacheKey() string {
+	values := map[string]interface{}{}
+	for _, key := range formatSettings {
+		values[key] = viper.Get(key)
+	}
+	settings, err := json.Marshal(values)
+	if err != nil {
+		settings = []byte(err.Error())
+	}
+	return fmt.Sprintf("%s\x00%s\x00%t", appVersion, settings, checkIdempotency)
 }
 
-func getStdinManager() ghokin.StdinManager {
+func getStdinManager() (ghokin.StdinManager, error) {
+	options, err := getFormatOptions()
+	if err != nil {
+		return ghokin.StdinManager{}, err
+	}
 	return ghokin.NewStdinManager(
 		viper.GetInt("indent"),
 		viper.GetStringMapString("aliases"),
-	)
+		options...,
+	), nil
+}
+
+// getIntFlagOrConfig returns the flag value when it has been
+// defined on the command line, the config value otherwise
+func getIntFlagOrConfig(flag int, key string) int {
+	if flag > 0 {
+		return flag
+	}
+	return viper.GetInt(key)
+}
+
+// getPaths returns paths given as arguments followed by the ones read from
+// the --files-from source, duplicates are removed
+func getPaths(cmd *cobra.Command, args []string) ([]string, error) {
+	paths := append([]string{}, args...)
+	if filesFrom != "" {
+		ps, err := readPaths(cmd.InOrStdin(), filesFrom)
+		if err != nil {
+			return []string{}, err
+		}
+		paths = append(paths, ps...)
+	}
+	seen := map[string]bool{}
+	uniquePaths := []string{}
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			uniquePaths = append(uniquePaths, path)
+		}
+	}
+	return uniquePaths, nil
+}
+
+// readPaths reads a list of paths separated with a NUL character or a new line
+// from a file, "-" means the list is read from stdin
+func readPaths(stdin io.Reader, source string) ([]string, error) {
+	var content []byte
+	var err error
+	if source == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(source) // #nosec
+	}
+	if err != nil {
+		return []string{}, err
+	}
+	separator := []byte("\n")
+	if bytes.Contains(content, []byte{0}) {
+		separator = []byte{0}
+	}
+	paths := []string{}
+	for _, path := range bytes.Split(content, separator) {
+		if p := strings.TrimRight(string(path), "\r\n"); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// describePaths quotes and joins paths to be displayed in a message
+func describePaths(paths []string, singular string, plural string) string {
+	quoted := []string{}
+	for _, path := range paths {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, path))
+	}
+	if len(paths) > 1 {
+		return strings.Join(quoted, ", ") + " " + plural
+	}
+	return strings.Join(quoted, ", ") + " " + singular
 }
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPaths(t *testing.T) {
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin-paths", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin-paths/list", []byte("a.feature\r\nb folder\n\nc.feature\n"), 0o777))
+
+	paths, err := readPaths(nil, "/tmp/ghokin-paths/list")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.feature", "b folder", "c.feature"}, paths)
+
+	paths, err = readPaths(bytes.NewBufferString("a.feature\x00b\nfolder\x00"), "-")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.feature", "b\nfolder"}, paths)
+
+	_, err = readPaths(nil, "/tmp/ghokin-paths/whatever")
+	assert.EqualError(t, err, "open /tmp/ghokin-paths/whatever: no such file or directory")
+}
+
+func TestGetFormatOptions(t *testing.T) {
+	defer viper.Reset()
+
+	options, err := getFormatOptions()
+	assert.NoError(t, err)
+	assert.Len(t, options, 6)
+
+	viper.Set("stepKeywords", "and")
+	options, err = getFormatOptions()
+	assert.NoError(t, err)
+	assert.Len(t, options, 7)
+
+	viper.Set("keywords", map[string]interface{}{"xx": map[string]interface{}{}})
+	_, err = getFormatOptions()
+	assert.EqualError(t, err, `language "xx" doesn't exist`)
+	viper.Set("keywords", map[string]interface{}{})
+
+	viper.Set("stepKeywords", "whatever")
+	_, err = getFormatOptions()
+	assert.EqualError(t, err, `step keyword style "whatever" doesn't exist, it must be one of preserve, and or explicit`)
+	_, err = getFileManager()
+	assert.Error(t, err)
+	_, err = getStdinManager()
+	assert.Error(t, err)
+}
+
+func TestGetTagStyle(t *testing.T) {
+	defer viper.Reset()
+
+	style, err := getTagStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, ghokin.TagStyle{}, style)
+
+	viper.Set("tags.sort", "priority")
+	viper.Set("tags.priority", []string{"@wip", "@smoke"})
+	viper.Set("tags.dedupe", true)
+	viper.Set("tags.lines", "merge")
+	viper.Set("tags.width", 80)
+	style, err = getTagStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, ghokin.TagStyle{
+		Sort:     ghokin.TagSortPriority,
+		Priority: []string{"@wip", "@smoke"},
+		Dedupe:   true,
+		Lines:    ghokin.TagLinesMerge,
+		Width:    80,
+	}, style)
+
+	viper.Set("tags.lines", "whatever")
+	_, err = getTagStyle()
+	assert.EqualError(t, err, `tag lines "whatever" doesn't exist, it must be one of preserve, merge or split`)
+
+	viper.Set("tags.sort", "whatever")
+	_, err = getTagStyle()
+	assert.EqualError(t, err, `tag sort "whatever" doesn't exist, it must be one of preserve, alphabetical or priority`)
+}
+
+func TestGetBlankLines(t *testing.T) {
+	defer viper.Reset()
+
+	assert.Equal(t, ghokin.BlankLines{
+		FeatureDescription: ghokin.BlankLinesPreserve,
+		AfterBackground:    ghokin.BlankLinesPreserve,
+		Scenario:           ghokin.BlankLinesPreserve,
+		Examples:           ghokin.BlankLinesPreserve,
+	}, getBlankLines())
+
+	viper.Set("blankLines.featureDescription", 0)
+	viper.Set("blankLines.afterBackground", 2)
+	viper.Set("blankLines.scenario", 1)
+	viper.Set("blankLines.collapse", true)
+	viper.Set("blankLines.trim", true)
+	assert.Equal(t, ghokin.BlankLines{
+		FeatureDescription: 0,
+		AfterBackground:    2,
+		Scenario:           1,
+		Examples:           ghokin.BlankLinesPreserve,
+		Collapse:           true,
+		Trim:               true,
+	}, getBlankLines())
+}
+
+func TestGetKeywords(t *testing.T) {
+	defer viper.Reset()
+
+	keywords, err := getKeywords()
+	assert.NoError(t, err)
+	assert.Equal(t, ghokin.Keywords{}, keywords)
+
+	viper.Set("keywords", map[string]interface{}{
+		"en": map[string]interface{}{"scenarioOutline": "Scenario Template"},
+	})
+	keywords, err = getKeywords()
+	assert.NoError(t, err)
+	assert.Equal(t, ghokin.Keywords{"en": {"scenarioOutline": "Scenario Template"}}, keywords)
+
+	viper.Set("keywords", map[string]interface{}{"en": "Scenario"})
+	_, err = getKeywords()
+	assert.EqualError(t, err, `check the keywords of the language "en" are a map`)
+}
+
+func TestGetCacheKey(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("indent", 2)
+	viper.Set("jobs", 4)
+	key := getCacheKey()
+
+	viper.Set("jobs", 8)
+	viper.Set("aliasJobs", 2)
+	viper.Set("cache.dir", "/tmp/ghokin-cache")
+	viper.Set("lint.rules", map[string]interface{}{"step-text": map[string]interface{}{"enabled": false}})
+	assert.Equal(t, key, getCacheKey())
+
+	for setting, value := range map[string]interface{}{
+		"indent":                    4,
+		"aliases":                   map[string]string{"json": "jq ."},
+		"stepKeywords":              "and",
+		"tags.sort":                 "alpha",
+		"blankLines.trim":           true,
+		"description.maxLineLength": 80,
+		"keywords":                  map[string]interface{}{"en": map[string]interface{}{"scenario": "Scenario"}},
+	} {
+		viper.Set(setting, value)
+		assert.NotEqual(t, key, getCacheKey(), setting)
+		key = getCacheKey()
+	}
+
+	checkIdempotency = true
+	defer func() { checkIdempotency = false }()
+	assert.NotEqual(t, key, getCacheKey())
+}
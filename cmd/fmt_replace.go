@@ -4,20 +4,36 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var extensions []string
+var (
+	extensions       []string
+	filesFrom        string
+	checkIdempotency bool
+	jobs             int
+	aliasJobs        int
+	noCache          bool
+)
 
 var fmtReplaceCmd = &cobra.Command{
-	Use:   "replace [file or folder path]",
-	Short: "Format and replace a file or a pool of files in folder",
+	Use:   "replace [file or folder path]...",
+	Short: "Format and replace files or pools of files in folders",
 	Run:   setupCmdFunc(formatAndReplace),
 }
 
 func formatAndReplace(msgHandler messageHandler, cmd *cobra.Command, args []string) {
-	if len(args) != 1 {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
 		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
 	}
 
-	if errs := getFileManager().TransformAndReplace(args[0], extensions); len(errs) > 0 {
+	fileManager, err := getFileManager()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if errs := fileManager.TransformAndReplace(paths, extensions).Errors(); len(errs) > 0 {
 		for _, e := range errs {
 			msgHandler.error(e)
 		}
@@ -25,10 +41,15 @@ func formatAndReplace(msgHandler messageHandler, cmd *cobra.Command, args []stri
 		msgHandler.exit(1)
 	}
 
-	msgHandler.success(`"%s" formatted`, args[0])
+	msgHandler.success("%s", describePaths(paths, "formatted", "formatted"))
 }
 
 func init() {
 	fmtReplaceCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	fmtReplaceCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to format from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	fmtReplaceCmd.Flags().BoolVar(&checkIdempotency, "check-idempotent", false, "Format every file twice and fail if the second pass changes the output")
+	fmtReplaceCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	fmtReplaceCmd.Flags().IntVar(&aliasJobs, "alias-jobs", 0, "Maximum number of alias commands running at the same time, 0 means no limit")
+	fmtReplaceCmd.Flags().BoolVar(&noCache, "no-cache", false, "Don't use the cache to skip files known to be well formatted")
 	fmtCmd.AddCommand(fmtReplaceCmd)
 }
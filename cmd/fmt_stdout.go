@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"github.com/antham/ghokin/v3/ghokin"
+
 	"github.com/spf13/cobra"
 )
 
@@ -14,9 +16,15 @@ func formatOnStdout(msgHandler messageHandler, cmd *cobra.Command, args []string
 	var output []byte
 	var err error
 	if len(args) == 0 {
-		output, err = getStdinManager().Transform(cmd.InOrStdin())
+		var stdinManager ghokin.StdinManager
+		if stdinManager, err = getStdinManager(); err == nil {
+			output, err = stdinManager.Transform(cmd.InOrStdin())
+		}
 	} else {
-		output, err = getFileManager().Transform(args[0])
+		var fileManager ghokin.FileManager
+		if fileManager, err = getFileManager(); err == nil {
+			output, err = fileManager.Transform(args[0])
+		}
 	}
 
 	if err != nil {
@@ -26,5 +34,6 @@ func formatOnStdout(msgHandler messageHandler, cmd *cobra.Command, args []string
 }
 
 func init() {
+	fmtStdoutCmd.Flags().BoolVar(&checkIdempotency, "check-idempotent", false, "Format the content twice and fail if the second pass changes the output")
 	fmtCmd.AddCommand(fmtStdoutCmd)
 }
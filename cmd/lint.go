@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	listRules bool
+	fix       bool
+	fixDryRun bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [file or folder path]...",
+	Short: "Report problems found in files/folders",
+	Long:  "Report problems found in files/folders with lint rules, it exits with an error code when an issue with the error severity is found, safe fixes are applied with --fix",
+	Run:   setupCmdFunc(lint),
+}
+
+func lint(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	linter, err := getLinter()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if listRules {
+		for _, rule := range linter.Rules() {
+			msgHandler.print("%s\n", rule)
+		}
+		return
+	}
+
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+
+	if fix || fixDryRun {
+		fileManager, err := getFileManager(ghokin.WithDryRun(fixDryRun))
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+		fixResults := fileManager.Fix(linter, paths, extensions)
+		for _, result := range fixResults {
+			if result.Diff != "" {
+				msgHandler.print("%s", result.Diff)
+			}
+		}
+		if errs := fixResults.Errors(); len(errs) > 0 {
+			for _, e := range errs {
+				msgHandler.error(e)
+			}
+			msgHandler.exit(1)
+			return
+		}
+		if fixDryRun {
+			return
+		}
+	}
+
+	results := linter.Lint(paths, extensions)
+	for _, e := range results.Errors {
+		msgHandler.error(e)
+	}
+	for _, issue := range results.Issues {
+		switch issue.Severity {
+		case ghokin.SeverityError:
+			msgHandler.error(errors.New(issue.String()))
+		case ghokin.SeverityWarning:
+			msgHandler.warning("%s", issue)
+		default:
+			msgHandler.print("%s\n", issue)
+		}
+	}
+
+	if results.Failed() {
+		msgHandler.exit(1)
+		return
+	}
+
+	if len(results.Issues) == 0 {
+		msgHandler.success("%s", describePaths(paths, "has no lint issues", "have no lint issues"))
+	}
+}
+
+func getLinter() (ghokin.Linter, error) {
+	configs := map[string]map[string]interface{}{}
+	for id, value := range viper.GetStringMap("lint.rules") {
+		config, ok := value.(map[string]interface{})
+		if !ok {
+			return ghokin.Linter{}, fmt.Errorf(`check the config of the lint rule "%s" is a map`, id)
+		}
+		configs[id] = config
+	}
+	return ghokin.NewLinter(
+		ghokin.RegisteredRules(),
+		configs,
+		ghokin.WithJobs(getIntFlagOrConfig(jobs, "jobs")),
+	)
+}
+
+func init() {
+	lintCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	lintCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to lint from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	lintCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	lintCmd.Flags().BoolVar(&fix, "fix", false, "Apply safe fixes and format fixed files, remaining issues are reported")
+	lintCmd.Flags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print the diff of safe fixes without changing files")
+	lintCmd.Flags().BoolVar(&listRules, "list-rules", false, "List available rules with their configuration")
+	rootCmd.AddCommand(lintCmd)
+}
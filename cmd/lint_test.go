@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		lint(msgHandler, &cobra.Command{}, []string{"fixtures/feature.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, "", stderr.String())
+}
+
+func TestLintErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		setup  func()
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			func() {},
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			func() {},
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+		{
+			func() {
+				viper.Set("lint.rules", map[string]interface{}{"whatever": map[string]interface{}{}})
+			},
+			[]string{"fixtures/feature.feature"},
+			"lint rule \"whatever\" doesn't exist\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		s.setup()
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			lint(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		viper.Reset()
+		stderr.Reset()
+		stdout.Reset()
+	}
+}
+
+func TestLintFix(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	content := `Feature: Fix
+
+  @wip @wip
+  Scenario: A scenario
+    Given a thing
+`
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/fix.feature", []byte(content), 0o777))
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	type scenario struct {
+		dryRun  bool
+		stdout  string
+		content string
+	}
+
+	scenarios := []scenario{
+		{
+			true,
+			`--- /tmp/ghokin/fix.feature
++++ /tmp/ghokin/fix.feature
+@@ -1,5 +1,5 @@
+ Feature: Fix
+ 
+-  @wip @wip
++  @wip
+   Scenario: A scenario
+     Given a thing
+`,
+			content,
+		},
+		{
+			false,
+			"\"/tmp/ghokin/fix.feature\" has no lint issues\n",
+			`Feature: Fix
+
+  @wip
+  Scenario: A scenario
+    Given a thing
+`,
+		},
+	}
+
+	for _, s := range scenarios {
+		fix, fixDryRun = !s.dryRun, s.dryRun
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			lint(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/fix.feature"})
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, "", stderr.String())
+		assert.EqualValues(t, s.stdout, stdout.String())
+		b, err := os.ReadFile("/tmp/ghokin/fix.feature")
+		assert.NoError(t, err)
+		assert.EqualValues(t, s.content, string(b))
+
+		stdout.Reset()
+	}
+
+	fix, fixDryRun = false, false
+}
@@ -47,3 +47,7 @@ func (m messageHandler) errorFatalStr(err string) {
 func (m messageHandler) success(str string, args ...interface{}) {
 	failOnFprintError(color.New(color.FgGreen).Fprintf(m.stdoutWriter, str+"\n", args...))
 }
+
+func (m messageHandler) warning(str string, args ...interface{}) {
+	failOnFprintError(color.New(color.FgYellow).Fprintf(m.stdoutWriter, str+"\n", args...))
+}
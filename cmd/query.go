@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	queryTags        string
+	queryExampleRows bool
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [file or folder path]...",
+	Short: "List scenarios of files/folders matching a tag expression",
+	Long:  "List scenarios matching a cucumber tag expression like \"@smoke and not @wip\" with their file and line, tags of features, rules and examples are inherited as when cucumber runs scenarios",
+	Run:   setupCmdFunc(query),
+}
+
+func query(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if queryTags == "" {
+		msgHandler.errorFatalStr("you must provide a tag expression with --tags")
+	}
+	expression, err := ghokin.ParseTagExpression(queryTags)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+
+	fileManager, err := getFileManager()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results := fileManager.Query(expression, paths, extensions)
+	if len(results.Errors) > 0 {
+		for _, e := range results.Errors {
+			msgHandler.error(e)
+		}
+
+		msgHandler.exit(1)
+		return
+	}
+
+	rows := 0
+	for _, match := range results.Matches {
+		switch {
+		case queryExampleRows && match.Outline:
+			msgHandler.print("%s:%d: %s (%d example rows)\n", match.File, match.Line, match.Name, match.ExampleRows)
+			rows += match.ExampleRows
+		case queryExampleRows:
+			msgHandler.print("%s:%d: %s\n", match.File, match.Line, match.Name)
+			rows++
+		default:
+			msgHandler.print("%s:%d: %s\n", match.File, match.Line, match.Name)
+		}
+	}
+	if queryExampleRows {
+		msgHandler.print("%d scenarios matching, %d once outlines are expanded\n", len(results.Matches), rows)
+	}
+}
+
+func init() {
+	queryCmd.Flags().StringVarP(&queryTags, "tags", "t", "", "Cucumber tag expression scenarios must match, like \"@smoke and not @wip\"")
+	queryCmd.Flags().BoolVar(&queryExampleRows, "example-rows", false, "Print the number of example rows matching outlines expand to")
+	queryCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	queryCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to analyze from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	queryCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	rootCmd.AddCommand(queryCmd)
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("@smoke\n"+
+		"Feature: Test\n"+
+		"  Scenario: Scenario1\n"+
+		"    Given a test\n"+
+		"\n"+
+		"  @wip\n"+
+		"  Scenario: Scenario2\n"+
+		"    Given a test\n"+
+		"\n"+
+		"  Scenario Outline: Outline1\n"+
+		"    Given a <test>\n"+
+		"\n"+
+		"    Examples:\n"+
+		"      | test |\n"+
+		"      | a    |\n"+
+		"      | b    |\n"), 0o755))
+
+	type scenario struct {
+		tags        string
+		exampleRows bool
+		expected    string
+	}
+
+	scenarios := []scenario{
+		{
+			"@smoke and not @wip",
+			false,
+			"/tmp/ghokin/file1.feature:3: Scenario1\n" +
+				"/tmp/ghokin/file1.feature:10: Outline1\n",
+		},
+		{
+			"@smoke and not @wip",
+			true,
+			"/tmp/ghokin/file1.feature:3: Scenario1\n" +
+				"/tmp/ghokin/file1.feature:10: Outline1 (2 example rows)\n" +
+				"2 scenarios matching, 3 once outlines are expanded\n",
+		},
+		{
+			"@unknown",
+			false,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		queryTags = s.tags
+		queryExampleRows = s.exampleRows
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			query(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin"})
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, s.expected, stdout.String())
+		assert.EqualValues(t, "", stderr.String())
+
+		stdout.Reset()
+	}
+
+	queryTags = ""
+	queryExampleRows = false
+}
+
+func TestQueryErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		tags   string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			"",
+			[]string{"fixtures/feature.feature"},
+			"you must provide a tag expression with --tags\n",
+		},
+		{
+			"@smoke and",
+			[]string{"fixtures/feature.feature"},
+			"tag expression \"@smoke and\" is invalid: a tag is expected at the end of the expression\n",
+		},
+		{
+			"@smoke",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"@smoke",
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		queryTags = s.tags
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			query(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+	}
+
+	queryTags = ""
+}
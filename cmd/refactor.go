@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var refactorDryRun bool
+
+var refactorCmd = &cobra.Command{
+	Use:   "refactor",
+	Short: "Change the content of files/folders in a structured way",
+	Run:   setupCmdFunc(manageRefactor),
+}
+
+func manageRefactor(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if err := cmd.Help(); err != nil {
+		msgHandler.errorFatal(err)
+	}
+}
+
+// reportRefactor prints diffs of a dry run and errors of a refactoring
+func reportRefactor(msgHandler messageHandler, results ghokin.ProcessFileResults, paths []string) {
+	for _, result := range results {
+		if result.Diff != "" {
+			msgHandler.print("%s", result.Diff)
+		}
+	}
+	if errs := results.Errors(); len(errs) > 0 {
+		for _, e := range errs {
+			msgHandler.error(e)
+		}
+
+		msgHandler.exit(1)
+		return
+	}
+
+	if !refactorDryRun {
+		msgHandler.success("%s", describePaths(paths, "refactored", "refactored"))
+	}
+}
+
+// changedPaths returns files written or removed by a refactoring producing several files,
+// paths are returned when none changed
+func changedPaths(results ghokin.ProcessFileResults, paths []string) []string {
+	changed := []string{}
+	for _, result := range results {
+		if result.Changed {
+			changed = append(changed, result.File)
+		}
+	}
+	if len(changed) == 0 {
+		return paths
+	}
+	return changed
+}
+
+func init() {
+	refactorCmd.PersistentFlags().BoolVar(&refactorDryRun, "dry-run", false, "Print the diff of changes without changing files")
+	refactorCmd.PersistentFlags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	refactorCmd.PersistentFlags().StringVar(&filesFrom, "files-from", "", "Read paths to refactor from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	refactorCmd.PersistentFlags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	rootCmd.AddCommand(refactorCmd)
+}
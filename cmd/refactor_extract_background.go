@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var refactorExtractBackgroundCmd = &cobra.Command{
+	Use:   "extract-background [file path]",
+	Short: "Move Given steps shared by all scenarios into a background",
+	Long:  "Move the longest common prefix of Given steps of scenarios into the background of their feature or of their rule, the background is created or extended",
+	Run:   setupCmdFunc(extractBackground),
+}
+
+func extractBackground(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("you must provide a single file as argument")
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	reportRefactor(msgHandler, fileManager.ExtractBackground(args[0]), args)
+}
+
+func init() {
+	refactorCmd.AddCommand(refactorExtractBackgroundCmd)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractBackground(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("Feature: Test\n  Scenario: Scenario1\n    Given a test\n    When a thing\n\n  Scenario: Scenario2\n    Given a test\n    Then a thing\n"), 0o755))
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		extractBackground(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, `"/tmp/ghokin/file1.feature" refactored`+"\n", stdout.String())
+	b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Feature: Test\n  Background:\n    Given a test\n\n  Scenario: Scenario1\n    When a thing\n\n  Scenario: Scenario2\n    Then a thing\n", string(b))
+}
+
+func TestExtractBackgroundErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			[]string{},
+			"you must provide a single file as argument\n",
+		},
+		{
+			[]string{"fixtures/feature.feature"},
+			"fixtures/feature.feature: scenarios don't start with common Given steps\n",
+		},
+		{
+			[]string{"fixtures"},
+			"fixtures: a file is expected\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			extractBackground(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeName   string
+	mergeOutput string
+)
+
+var refactorMergeCmd = &cobra.Command{
+	Use:   "merge [file or folder path]...",
+	Short: "Merge features into a single feature",
+	Long:  "Merge features into the file given with --output, each feature becomes a rule of a feature named with --name, merged files are removed",
+	Run:   setupCmdFunc(mergeFiles),
+}
+
+func mergeFiles(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+	if mergeOutput == "" {
+		msgHandler.errorFatalStr("you must provide an output file with --output")
+	}
+	if mergeName == "" {
+		msgHandler.errorFatalStr("you must provide a feature name with --name")
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results := fileManager.Merge(mergeName, mergeOutput, paths, extensions)
+	reportRefactor(msgHandler, results, changedPaths(results, paths))
+}
+
+func init() {
+	refactorMergeCmd.Flags().StringVar(&mergeName, "name", "", "Name of the merged feature")
+	refactorMergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "File receiving the merged feature")
+	refactorCmd.AddCommand(refactorMergeCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scenarioLine int
+	scenarioName string
+)
+
+var refactorOutlineCmd = &cobra.Command{
+	Use:   "outline [file path]",
+	Short: "Merge similar scenarios into a scenario outline",
+	Long:  "Merge the scenario selected with --line or --name and the scenarios of the same feature or rule which only differ by quoted strings, numbers or table cells into a scenario outline, differing values are moved to examples",
+	Run:   setupCmdFunc(convertToOutline),
+}
+
+var refactorExpandOutlineCmd = &cobra.Command{
+	Use:   "expand-outline [file path]",
+	Short: "Expand a scenario outline into scenarios",
+	Long:  "Replace the scenario outline selected with --line or --name with a scenario per examples row, placeholders are substituted and tags of examples are carried over",
+	Run:   setupCmdFunc(expandOutline),
+}
+
+func convertToOutline(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	refactorScenario(msgHandler, args, ghokin.FileManager.ConvertToOutline)
+}
+
+func expandOutline(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	refactorScenario(msgHandler, args, ghokin.FileManager.ExpandOutline)
+}
+
+func refactorScenario(msgHandler messageHandler, args []string, refactoring func(ghokin.FileManager, string, ghokin.ScenarioSelector) ghokin.ProcessFileResults) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("you must provide a single file as argument")
+	}
+	if (scenarioLine > 0) == (scenarioName != "") {
+		msgHandler.errorFatalStr("you must select a scenario either with --line or with --name")
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	reportRefactor(msgHandler, refactoring(fileManager, args[0], ghokin.ScenarioSelector{Line: scenarioLine, Name: scenarioName}), args)
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{refactorOutlineCmd, refactorExpandOutlineCmd} {
+		cmd.Flags().IntVar(&scenarioLine, "line", 0, "Select the scenario containing this line")
+		cmd.Flags().StringVar(&scenarioName, "name", "", "Select the scenario with this name")
+		refactorCmd.AddCommand(cmd)
+	}
+}
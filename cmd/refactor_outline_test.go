@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToOutlineAndExpandOutline(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	content := "Feature: Test\n  Scenario: Scenario1\n    Given 1 test\n\n  Scenario: Scenario1\n    Given 2 test\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte(content), 0o755))
+
+	defer func() {
+		scenarioLine = 0
+		scenarioName = ""
+	}()
+
+	type scenario struct {
+		refactoring func(messageHandler, *cobra.Command, []string)
+		line        int
+		expected    string
+	}
+
+	scenarios := []scenario{
+		{
+			convertToOutline,
+			3,
+			"Feature: Test\n  Scenario Outline: Scenario1\n    Given <test> test\n\n    Examples:\n      | test |\n      | 1    |\n      | 2    |\n",
+		},
+		{
+			expandOutline,
+			2,
+			"Feature: Test\n  Scenario: Scenario1 (1)\n    Given 1 test\n\n  Scenario: Scenario1 (2)\n    Given 2 test\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		scenarioLine = s.line
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			s.refactoring(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, `"/tmp/ghokin/file1.feature" refactored`+"\n", stdout.String())
+		b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+		assert.NoError(t, err)
+		assert.EqualValues(t, s.expected, string(b))
+
+		stdout.Reset()
+	}
+}
+
+func TestConvertToOutlineErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		line   int
+		name   string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			1,
+			"",
+			[]string{},
+			"you must provide a single file as argument\n",
+		},
+		{
+			0,
+			"",
+			[]string{"fixtures/feature.feature"},
+			"you must select a scenario either with --line or with --name\n",
+		},
+		{
+			1,
+			"A scenario",
+			[]string{"fixtures/feature.feature"},
+			"you must select a scenario either with --line or with --name\n",
+		},
+		{
+			0,
+			"whatever",
+			[]string{"fixtures/feature.feature"},
+			"fixtures/feature.feature: no scenario named \"whatever\"\n",
+		},
+		{
+			0,
+			"whatever",
+			[]string{"fixtures"},
+			"fixtures: a file is expected\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		scenarioLine = s.line
+		scenarioName = s.name
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			convertToOutline(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	scenarioLine = 0
+	scenarioName = ""
+}
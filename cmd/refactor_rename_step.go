@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	renameStepFrom string
+	renameStepTo   string
+)
+
+var refactorRenameStepCmd = &cobra.Command{
+	Use:   "rename-step [file or folder path]...",
+	Short: "Rename steps matching a regular expression or a cucumber expression",
+	Long:  "Rename steps whose text matches --from with the template --to, a pattern starting with ^, ending with $ or surrounded with slashes is a regular expression, any other pattern is a cucumber expression, captured groups are reused in the template with $1, $2...",
+	Run:   setupCmdFunc(renameStep),
+}
+
+func renameStep(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+	if renameStepFrom == "" {
+		msgHandler.errorFatalStr("you must provide a step pattern with --from")
+	}
+
+	pattern, err := ghokin.ParseStepPattern(renameStepFrom)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	reportRefactor(msgHandler, fileManager.RenameStep(pattern, renameStepTo, paths, extensions), paths)
+}
+
+func init() {
+	refactorRenameStepCmd.Flags().StringVar(&renameStepFrom, "from", "", "Regular expression or cucumber expression matching texts of steps to rename")
+	refactorRenameStepCmd.Flags().StringVar(&renameStepTo, "to", "", "New text of steps, $1, $2... are replaced with captured groups")
+	refactorCmd.AddCommand(refactorRenameStepCmd)
+}
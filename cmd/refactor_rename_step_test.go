@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameStep(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	content := "Feature: Test\n  Scenario: Scenario1\n    Given I have 3 cukes\n      | a |\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte(content), 0o755))
+
+	renameStepFrom = "I have {int} cuke(s)"
+	renameStepTo = "I own $1 cucumbers"
+	defer func() {
+		renameStepFrom = ""
+		renameStepTo = ""
+		refactorDryRun = false
+	}()
+
+	for _, dryRun := range []bool{true, false} {
+		refactorDryRun = dryRun
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			renameStep(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, "", stderr.String())
+	}
+
+	assert.True(t, strings.Contains(stdout.String(), "-    Given I have 3 cukes\n+    Given I own 3 cucumbers\n"))
+	assert.True(t, strings.HasSuffix(stdout.String(), `"/tmp/ghokin/file1.feature" refactored`+"\n"))
+	b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Feature: Test\n  Scenario: Scenario1\n    Given I own 3 cucumbers\n      | a |\n", string(b))
+}
+
+func TestRenameStepErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		from   string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			"a step",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"",
+			[]string{"fixtures/feature.feature"},
+			"you must provide a step pattern with --from\n",
+		},
+		{
+			"a {whatever}",
+			[]string{"fixtures/feature.feature"},
+			"parameter type \"{whatever}\" doesn't exist\n",
+		},
+		{
+			"^a (step$",
+			[]string{"fixtures/feature.feature"},
+			"error parsing regexp: missing closing ): `^a (step$`\n",
+		},
+		{
+			"a step",
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		renameStepFrom = s.from
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			renameStep(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	renameStepFrom = ""
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	sortScenariosBy string
+	examplesColumn  string
+)
+
+var refactorSortCmd = &cobra.Command{
+	Use:   "sort [file or folder path]...",
+	Short: "Sort scenarios and examples rows",
+	Long:  "Sort scenarios of every feature and rule by name or by tag with --by, comments and tags of a scenario move with it, and sort rows of examples by a column with --examples-column",
+	Run:   setupCmdFunc(sortFiles),
+}
+
+func sortFiles(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+
+	scenarioSort, err := ghokin.ParseScenarioSort(sortScenariosBy)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if scenarioSort == ghokin.ScenarioSortPreserve && examplesColumn == "" {
+		msgHandler.errorFatalStr("you must provide a scenario sort with --by or an examples column with --examples-column")
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	reportRefactor(msgHandler, fileManager.Sort(scenarioSort, examplesColumn, paths, extensions), paths)
+}
+
+func init() {
+	refactorSortCmd.Flags().StringVar(&sortScenariosBy, "by", "preserve", "Sort scenarios by name or by tag, scenarios are kept in place with preserve")
+	refactorSortCmd.Flags().StringVar(&examplesColumn, "examples-column", "", "Sort rows of examples by the values of this column")
+	refactorCmd.AddCommand(refactorSortCmd)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortFiles(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("Feature: Test\n  Scenario: B\n    Given a test\n\n  Scenario: A\n    Given a test\n"), 0o755))
+
+	sortScenariosBy = "name"
+	defer func() { sortScenariosBy = "preserve" }()
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		sortFiles(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, `"/tmp/ghokin/file1.feature" refactored`+"\n", stdout.String())
+	b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Feature: Test\n  Scenario: A\n    Given a test\n\n  Scenario: B\n    Given a test\n", string(b))
+}
+
+func TestSortFilesErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		by     string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			"name",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"whatever",
+			[]string{"fixtures/feature.feature"},
+			"scenario sort \"whatever\" doesn't exist, it must be one of preserve, name or tag\n",
+		},
+		{
+			"preserve",
+			[]string{"fixtures/feature.feature"},
+			"you must provide a scenario sort with --by or an examples column with --examples-column\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		sortScenariosBy = s.by
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			sortFiles(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	sortScenariosBy = "preserve"
+}
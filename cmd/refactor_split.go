@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var splitTags []string
+
+var refactorSplitCmd = &cobra.Command{
+	Use:   "split [file path]",
+	Short: "Split a feature into a file per rule or per tag",
+	Long:  "Move every rule of a feature, or the scenarios having one of the tags given with --tag, in a file of its own named after the rule or the tag, the header, the description and the background of the feature are copied in each file",
+	Run:   setupCmdFunc(splitFile),
+}
+
+func splitFile(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("you must provide a single file as argument")
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results := fileManager.Split(args[0], splitTags)
+	reportRefactor(msgHandler, results, changedPaths(results, args))
+}
+
+func init() {
+	refactorSplitCmd.Flags().StringArrayVar(&splitTags, "tag", []string{}, "Split scenarios having this tag instead of rules, the flag can be repeated")
+	refactorCmd.AddCommand(refactorSplitCmd)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFileAndMergeFiles(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	content := "Feature: Shop\n\n  Rule: Buy\n\n    Scenario: Buy\n      When he buys\n\n  Rule: Sell\n\n    Scenario: Sell\n      When he sells\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/shop.feature", []byte(content), 0o755))
+
+	mergeName = "Shop"
+	mergeOutput = "/tmp/ghokin/shop.feature"
+	defer func() {
+		mergeName = ""
+		mergeOutput = ""
+	}()
+
+	type scenario struct {
+		refactoring func(messageHandler, *cobra.Command, []string)
+		args        []string
+		stdout      string
+		files       map[string]string
+	}
+
+	scenarios := []scenario{
+		{
+			splitFile,
+			[]string{"/tmp/ghokin/shop.feature"},
+			`"/tmp/ghokin/shop-buy.feature", "/tmp/ghokin/shop-sell.feature", "/tmp/ghokin/shop.feature" refactored` + "\n",
+			map[string]string{
+				"/tmp/ghokin/shop-buy.feature":  "Feature: Shop\n\n  Rule: Buy\n\n    Scenario: Buy\n      When he buys\n",
+				"/tmp/ghokin/shop-sell.feature": "Feature: Shop\n\n  Rule: Sell\n\n    Scenario: Sell\n      When he sells\n",
+			},
+		},
+		{
+			mergeFiles,
+			[]string{"/tmp/ghokin/shop-buy.feature", "/tmp/ghokin/shop-sell.feature"},
+			`"/tmp/ghokin/shop-buy.feature", "/tmp/ghokin/shop-sell.feature", "/tmp/ghokin/shop.feature" refactored` + "\n",
+			map[string]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			s.refactoring(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, "", stderr.String())
+		assert.EqualValues(t, s.stdout, stdout.String())
+		for file, expected := range s.files {
+			b, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.EqualValues(t, expected, string(b))
+		}
+
+		stdout.Reset()
+	}
+
+	_, err := os.Stat("/tmp/ghokin/shop-buy.feature")
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat("/tmp/ghokin/shop-sell.feature")
+	assert.True(t, os.IsNotExist(err))
+	b, err := os.ReadFile("/tmp/ghokin/shop.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, content, string(b))
+}
+
+func TestMergeFilesErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		name   string
+		output string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			"Shop",
+			"/tmp/ghokin/shop.feature",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"Shop",
+			"",
+			[]string{"fixtures/feature.feature"},
+			"you must provide an output file with --output\n",
+		},
+		{
+			"",
+			"/tmp/ghokin/shop.feature",
+			[]string{"fixtures/feature.feature"},
+			"you must provide a feature name with --name\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		mergeName = s.name
+		mergeOutput = s.output
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			mergeFiles(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	mergeName = ""
+	mergeOutput = ""
+}
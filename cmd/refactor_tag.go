@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagRenames  []string
+	tagRemovals []string
+)
+
+var refactorTagCmd = &cobra.Command{
+	Use:   "tag [file or folder path]...",
+	Short: "Rename or remove tags",
+	Long:  "Rename tags with --rename @old=@new and remove tags with --remove @obsolete on features, rules, scenarios and examples, tag lines left empty are deleted",
+	Run:   setupCmdFunc(rewriteTags),
+}
+
+func rewriteTags(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+	if len(tagRenames) == 0 && len(tagRemovals) == 0 {
+		msgHandler.errorFatalStr("you must provide tags to rename with --rename or to remove with --remove")
+	}
+
+	renames, err := getTagRenames()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	fileManager, err := getFileManager(ghokin.WithDryRun(refactorDryRun))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results, err := fileManager.RewriteTags(renames, tagRemovals, paths, extensions)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	reportRefactor(msgHandler, results, paths)
+}
+
+func getTagRenames() (map[string]string, error) {
+	renames := map[string]string{}
+	for _, rename := range tagRenames {
+		from, to, ok := strings.Cut(rename, "=")
+		if !ok {
+			return map[string]string{}, fmt.Errorf(`tag rename "%s" must be formatted as @old=@new`, rename)
+		}
+		renames[strings.TrimSpace(from)] = strings.TrimSpace(to)
+	}
+	return renames, nil
+}
+
+func init() {
+	refactorTagCmd.Flags().StringArrayVar(&tagRenames, "rename", []string{}, "Rename a tag, formatted as @old=@new, the flag can be repeated")
+	refactorTagCmd.Flags().StringArrayVar(&tagRemovals, "remove", []string{}, "Remove a tag, the flag can be repeated")
+	refactorCmd.AddCommand(refactorTagCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteTags(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("@old @wip\nFeature: Test\n  @wip\n  Scenario: Scenario1\n    Given a test\n"), 0o755))
+
+	tagRenames = []string{"@old=@new"}
+	tagRemovals = []string{"@wip"}
+	defer func() {
+		tagRenames = []string{}
+		tagRemovals = []string{}
+	}()
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		rewriteTags(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, `"/tmp/ghokin/file1.feature" refactored`+"\n", stdout.String())
+	b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "@new\nFeature: Test\n  Scenario: Scenario1\n    Given a test\n", string(b))
+}
+
+func TestRewriteTagsErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		renames  []string
+		removals []string
+		args     []string
+		errMsg   string
+	}
+
+	scenarios := []scenario{
+		{
+			[]string{},
+			[]string{"@wip"},
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			[]string{},
+			[]string{},
+			[]string{"fixtures/feature.feature"},
+			"you must provide tags to rename with --rename or to remove with --remove\n",
+		},
+		{
+			[]string{"@old"},
+			[]string{},
+			[]string{"fixtures/feature.feature"},
+			"tag rename \"@old\" must be formatted as @old=@new\n",
+		},
+		{
+			[]string{},
+			[]string{"wip"},
+			[]string{"fixtures/feature.feature"},
+			"tag \"wip\" must start with @ and can't contain spaces\n",
+		},
+		{
+			[]string{},
+			[]string{"@wip"},
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		tagRenames = s.renames
+		tagRemovals = s.removals
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			rewriteTags(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	tagRenames = []string{}
+	tagRemovals = []string{}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManageRefactor(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	manageRefactor(msgHandler, &cobra.Command{}, []string{})
+
+	assert.EqualValues(t, "", stdout.String())
+	assert.EqualValues(t, "", stderr.String())
+}
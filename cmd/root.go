@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -46,6 +49,10 @@ func initConfig(msgHandler messageHandler) func() {
 		viper.SetEnvPrefix("ghokin")
 		for _, err := range []error{
 			viper.BindEnv("indent"),
+			viper.BindEnv("jobs"),
+			viper.BindEnv("aliasJobs", "GHOKIN_ALIAS_JOBS"),
+			viper.BindEnv("stepKeywords", "GHOKIN_STEP_KEYWORDS"),
+			viper.BindEnv("description.maxLineLength", "GHOKIN_DESCRIPTION_MAX_LINE_LENGTH"),
 		} {
 			if err != nil {
 				msgHandler.errorFatal(err)
@@ -55,6 +62,24 @@ func initConfig(msgHandler messageHandler) func() {
 		viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 		viper.AutomaticEnv()
 		viper.SetDefault("indent", 2)
+		viper.SetDefault("jobs", runtime.NumCPU())
+		viper.SetDefault("aliasJobs", 0)
+		viper.SetDefault("stepKeywords", "preserve")
+		viper.SetDefault("tags.sort", "preserve")
+		viper.SetDefault("tags.priority", []string{})
+		viper.SetDefault("tags.dedupe", false)
+		viper.SetDefault("tags.lines", "preserve")
+		viper.SetDefault("tags.width", 0)
+		for _, key := range []string{"featureDescription", "afterBackground", "scenario", "examples"} {
+			viper.SetDefault("blankLines."+key, -1)
+		}
+		viper.SetDefault("blankLines.collapse", false)
+		viper.SetDefault("blankLines.trim", false)
+		viper.SetDefault("description.maxLineLength", 0)
+		viper.SetDefault("cache.enabled", false)
+		if dir, err := os.UserCacheDir(); err == nil {
+			viper.SetDefault("cache.dir", filepath.Join(dir, "ghokin"))
+		}
 
 		aliases := map[string]string{}
 		if err := json.Unmarshal([]byte(viper.GetString("aliases")), &aliases); viper.IsSet("aliases") && err != nil {
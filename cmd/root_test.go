@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"runtime"
 	"sync"
 	"testing"
 
@@ -37,6 +38,18 @@ func TestInitConfig(t *testing.T) {
 			func(exitCode int, stdin string, stderr string) {
 				assert.EqualValues(t, 2, viper.GetInt("indent"))
 				assert.EqualValues(t, map[string]string{}, viper.GetStringMapString("aliases"))
+				assert.EqualValues(t, runtime.NumCPU(), viper.GetInt("jobs"))
+				assert.EqualValues(t, 0, viper.GetInt("aliasJobs"))
+				assert.EqualValues(t, "preserve", viper.GetString("stepKeywords"))
+				assert.EqualValues(t, "preserve", viper.GetString("tags.sort"))
+				assert.EqualValues(t, "preserve", viper.GetString("tags.lines"))
+				assert.False(t, viper.GetBool("tags.dedupe"))
+				assert.EqualValues(t, 0, viper.GetInt("tags.width"))
+				assert.EqualValues(t, -1, viper.GetInt("blankLines.scenario"))
+				assert.False(t, viper.GetBool("blankLines.collapse"))
+				assert.EqualValues(t, 0, viper.GetInt("description.maxLineLength"))
+				assert.False(t, viper.GetBool("cache.enabled"))
+				assert.Contains(t, viper.GetString("cache.dir"), "ghokin")
 			},
 			func() {},
 		},
@@ -44,14 +57,26 @@ func TestInitConfig(t *testing.T) {
 			func() {
 				assert.NoError(t, os.Setenv("GHOKIN_INDENT", "1"))
 				assert.NoError(t, os.Setenv("GHOKIN_ALIASES", `{"json":"jq"}`))
+				assert.NoError(t, os.Setenv("GHOKIN_JOBS", "3"))
+				assert.NoError(t, os.Setenv("GHOKIN_ALIAS_JOBS", "1"))
+				assert.NoError(t, os.Setenv("GHOKIN_STEP_KEYWORDS", "and"))
+				assert.NoError(t, os.Setenv("GHOKIN_DESCRIPTION_MAX_LINE_LENGTH", "80"))
 			},
 			func(exitCode int, stdin string, stderr string) {
 				assert.EqualValues(t, 1, viper.GetInt("indent"))
 				assert.EqualValues(t, map[string]string{"json": "jq"}, viper.GetStringMapString("aliases"))
+				assert.EqualValues(t, 3, viper.GetInt("jobs"))
+				assert.EqualValues(t, 1, viper.GetInt("aliasJobs"))
+				assert.EqualValues(t, "and", viper.GetString("stepKeywords"))
+				assert.EqualValues(t, 80, viper.GetInt("description.maxLineLength"))
 			},
 			func() {
 				assert.NoError(t, os.Unsetenv("GHOKIN_INDENT"))
 				assert.NoError(t, os.Unsetenv("GHOKIN_ALIASES"))
+				assert.NoError(t, os.Unsetenv("GHOKIN_JOBS"))
+				assert.NoError(t, os.Unsetenv("GHOKIN_ALIAS_JOBS"))
+				assert.NoError(t, os.Unsetenv("GHOKIN_STEP_KEYWORDS"))
+				assert.NoError(t, os.Unsetenv("GHOKIN_DESCRIPTION_MAX_LINE_LENGTH"))
 			},
 		},
 		{
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/antham/ghokin/v3/ghokin"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsFormat string
+	statsTop    int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [file or folder path]...",
+	Short: "Report statistics on files/folders",
+	Long:  "Report counts of features, rules, scenarios, outlines, example rows and steps by keyword, the frequency of tags, the largest scenarios and the number of files per language, as a table, JSON or CSV",
+	Run:   setupCmdFunc(stats),
+}
+
+func stats(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+
+	render, ok := map[string]func(ghokin.Stats) (string, error){
+		"table": renderStatsTable,
+		"json":  renderStatsJSON,
+		"csv":   renderStatsCSV,
+	}[statsFormat]
+	if !ok {
+		msgHandler.errorFatalStr(fmt.Sprintf(`format "%s" doesn't exist, it must be one of table, json or csv`, statsFormat))
+	}
+
+	fileManager, err := getFileManager()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results := fileManager.Stats(paths, extensions, statsTop)
+	if len(results.Errors) > 0 {
+		for _, e := range results.Errors {
+			msgHandler.error(e)
+		}
+
+		msgHandler.exit(1)
+		return
+	}
+
+	output, err := render(results.Stats)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	msgHandler.print("%s", output)
+}
+
+// statsRows flattens statistics in rows made of a section, a name and a value
+func statsRows(stats ghokin.Stats) [][]string {
+	rows := [][]string{
+		{"count", "files", strconv.Itoa(stats.Files)},
+		{"count", "features", strconv.Itoa(stats.Features)},
+		{"count", "rules", strconv.Itoa(stats.Rules)},
+		{"count", "scenarios", strconv.Itoa(stats.Scenarios)},
+		{"count", "outlines", strconv.Itoa(stats.Outlines)},
+		{"count", "example rows", strconv.Itoa(stats.ExampleRows)},
+	}
+	for _, kind := range ghokin.StepKinds() {
+		rows = append(rows, []string{"steps", kind, strconv.Itoa(stats.Steps[kind])})
+	}
+	for _, tag := range stats.Tags {
+		rows = append(rows, []string{"tags", tag.Tag, strconv.Itoa(tag.Count)})
+	}
+	for _, scenario := range stats.LargestScenarios {
+		rows = append(rows, []string{"largest scenarios", fmt.Sprintf("%s:%d %s", scenario.File, scenario.Line, scenario.Name), strconv.Itoa(scenario.Steps)})
+	}
+	languages := []string{}
+	for language := range stats.Languages {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		rows = append(rows, []string{"languages", language, strconv.Itoa(stats.Languages[language])})
+	}
+	return rows
+}
+
+func renderStatsTable(stats ghokin.Stats) (string, error) {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	section := ""
+	for _, row := range statsRows(stats) {
+		if row[0] != section {
+			if section != "" {
+				fmt.Fprintln(w)
+			}
+			section = row[0]
+			fmt.Fprintf(w, "%s\n", section)
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", row[1], row[2])
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func renderStatsJSON(stats ghokin.Stats) (string, error) {
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func renderStatsCSV(stats ghokin.Stats) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.WriteAll(append([][]string{{"section", "name", "value"}}, statsRows(stats)...)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsFormat, "format", "f", "table", "Output format, one of table, json or csv")
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "Number of largest scenarios to report")
+	statsCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	statsCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to analyze from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	statsCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	rootCmd.AddCommand(statsCmd)
+}
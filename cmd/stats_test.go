@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("@smoke\nFeature: Test\n  Scenario: Scenario1\n    Given a test\n"), 0o755))
+
+	type scenario struct {
+		format   string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"table",
+			"count\n" +
+				"  files         1\n" +
+				"  features      1\n" +
+				"  rules         0\n" +
+				"  scenarios     1\n" +
+				"  outlines      0\n" +
+				"  example rows  0\n" +
+				"\n" +
+				"steps\n" +
+				"  given  1\n" +
+				"  when   0\n" +
+				"  then   0\n" +
+				"  and    0\n" +
+				"  but    0\n" +
+				"  *      0\n" +
+				"\n" +
+				"tags\n" +
+				"  @smoke  1\n" +
+				"\n" +
+				"largest scenarios\n" +
+				"  /tmp/ghokin/file1.feature:3 Scenario1  1\n" +
+				"\n" +
+				"languages\n" +
+				"  en  1\n",
+		},
+		{
+			"csv",
+			"section,name,value\n" +
+				"count,files,1\n" +
+				"count,features,1\n" +
+				"count,rules,0\n" +
+				"count,scenarios,1\n" +
+				"count,outlines,0\n" +
+				"count,example rows,0\n" +
+				"steps,given,1\n" +
+				"steps,when,0\n" +
+				"steps,then,0\n" +
+				"steps,and,0\n" +
+				"steps,but,0\n" +
+				"steps,*,0\n" +
+				"tags,@smoke,1\n" +
+				"largest scenarios,/tmp/ghokin/file1.feature:3 Scenario1,1\n" +
+				"languages,en,1\n",
+		},
+		{
+			"json",
+			`{
+  "files": 1,
+  "features": 1,
+  "rules": 0,
+  "scenarios": 1,
+  "outlines": 0,
+  "exampleRows": 0,
+  "steps": {
+    "*": 0,
+    "and": 0,
+    "but": 0,
+    "given": 1,
+    "then": 0,
+    "when": 0
+  },
+  "tags": [
+    {
+      "tag": "@smoke",
+      "count": 1
+    }
+  ],
+  "largestScenarios": [
+    {
+      "file": "/tmp/ghokin/file1.feature",
+      "line": 3,
+      "name": "Scenario1",
+      "steps": 1
+    }
+  ],
+  "languages": {
+    "en": 1
+  }
+}
+`,
+		},
+	}
+
+	for _, s := range scenarios {
+		statsFormat = s.format
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			stats(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin"})
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+		assert.EqualValues(t, s.expected, stdout.String())
+		assert.EqualValues(t, "", stderr.String())
+
+		stdout.Reset()
+	}
+
+	statsFormat = "table"
+}
+
+func TestStatsErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		format string
+		args   []string
+		errMsg string
+	}
+
+	scenarios := []scenario{
+		{
+			"table",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"xml",
+			[]string{"fixtures/feature.feature"},
+			"format \"xml\" doesn't exist, it must be one of table, json or csv\n",
+		},
+		{
+			"table",
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		statsFormat = s.format
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			stats(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	statsFormat = "table"
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var language string
+
+var translateCmd = &cobra.Command{
+	Use:   "translate [file or folder path]...",
+	Short: "Translate keywords of files/folders in another language",
+	Long:  "Translate keywords of files/folders in the gherkin dialect of another language and format them, step texts, tables and doc strings are kept as they are",
+	Run:   setupCmdFunc(translate),
+}
+
+func translate(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	paths, err := getPaths(cmd, args)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if len(paths) == 0 {
+		msgHandler.errorFatalStr("you must provide a filename or a folder as argument")
+	}
+	if language == "" {
+		msgHandler.errorFatalStr("you must provide a language with --to")
+	}
+
+	fileManager, err := getFileManager()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	results, err := fileManager.Translate(language, paths, extensions)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+	if errs := results.Errors(); len(errs) > 0 {
+		for _, e := range errs {
+			msgHandler.error(e)
+		}
+
+		msgHandler.exit(1)
+	}
+
+	msgHandler.success("%s", describePaths(paths, "translated", "translated"))
+}
+
+func init() {
+	translateCmd.Flags().StringVar(&language, "to", "", "Language to translate keywords to, for instance fr")
+	translateCmd.Flags().StringSliceVarP(&extensions, "extensions", "e", []string{"feature"}, "Define file extensions to use to find feature files, each separated with a comma")
+	translateCmd.Flags().StringVar(&filesFrom, "files-from", "", "Read paths to translate from a file, or from stdin with -, paths are separated with a new line or a NUL character")
+	translateCmd.Flags().IntVarP(&jobs, "jobs", "j", 0, "Number of files processed concurrently, it defaults to the number of CPUs")
+	rootCmd.AddCommand(translateCmd)
+}
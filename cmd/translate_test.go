@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	viper.Set("indent", 2)
+	defer viper.Reset()
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("Feature: Test\n  Scenario: Scenario1\n    Given a test\n"), 0o755))
+
+	language = "de"
+	defer func() { language = "" }()
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		translate(msgHandler, &cobra.Command{}, []string{"/tmp/ghokin/file1.feature"})
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit with no errors (exit 0)")
+	assert.EqualValues(t, `"/tmp/ghokin/file1.feature" translated`+"\n", stdout.String())
+	b, err := os.ReadFile("/tmp/ghokin/file1.feature")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "# language: de\nFunktionalität: Test\n  Szenario: Scenario1\n    Angenommen a test\n", string(b))
+}
+
+func TestTranslateErrors(t *testing.T) {
+	var code int
+	var w sync.WaitGroup
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	type scenario struct {
+		language string
+		args     []string
+		errMsg   string
+	}
+
+	scenarios := []scenario{
+		{
+			"fr",
+			[]string{},
+			"you must provide a filename or a folder as argument\n",
+		},
+		{
+			"",
+			[]string{"fixtures/feature.feature"},
+			"you must provide a language with --to\n",
+		},
+		{
+			"whatever",
+			[]string{"fixtures/feature.feature"},
+			"language \"whatever\" doesn't exist\n",
+		},
+		{
+			"fr",
+			[]string{"fixtures/file.txt"},
+			"Parser errors:\n(1:1): expected: #EOF, #Language, #TagLine, #FeatureLine, #Comment, #Empty, got 'Whatever'\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		language = s.language
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			translate(msgHandler, &cobra.Command{}, s.args)
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, 1, code, "Must exit with errors (exit 1)")
+		assert.EqualValues(t, s.errMsg, stderr.String())
+
+		stderr.Reset()
+		stdout.Reset()
+	}
+
+	language = ""
+}
@@ -0,0 +1,114 @@
+package ghokin
+
+import (
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v28"
+)
+
+// BlankLinesPreserve keeps the empty lines found in the original content,
+// any negative value has the same effect
+const BlankLinesPreserve = -1
+
+// BlankLines defines how many empty lines are put between elements, tags and comments
+// directly preceding an element are part of it, BlankLinesPreserve keeps original empty lines.
+// FeatureDescription applies between the feature line and its description, AfterBackground
+// before the first scenario or rule following a background, Scenario before any other scenario
+// or rule and Examples before examples. Collapse reduces runs of empty lines to a single one
+// and Trim removes empty lines at the beginning and at the end of the content
+type BlankLines struct {
+	FeatureDescription int
+	AfterBackground    int
+	Scenario           int
+	Examples           int
+	Collapse           bool
+	Trim               bool
+}
+
+// before returns how many empty lines must precede a section, BlankLinesPreserve
+// is returned when the section doesn't start an element or when the policy preserves them
+func (b BlankLines) before(sec *section, previousElement gherkin.TokenType) int {
+	if sec.kind == gherkin.TokenTypeOther && isDescriptionFeature(sec) {
+		return b.FeatureDescription
+	}
+	element := startedElement(sec)
+	if element == nil {
+		return BlankLinesPreserve
+	}
+	switch element.kind {
+	case gherkin.TokenTypeScenarioLine, gherkin.TokenTypeRuleLine:
+		if previousElement == gherkin.TokenTypeBackgroundLine {
+			return b.AfterBackground
+		}
+		return b.Scenario
+	case gherkin.TokenTypeExamplesLine:
+		return b.Examples
+	}
+	return BlankLinesPreserve
+}
+
+// startedElement returns the element line whose block starts with the section,
+// nil is returned if the section doesn't start a block
+func startedElement(sec *section) *section {
+	isAttached := func(s *section) bool {
+		return s.kind == gherkin.TokenTypeTagLine || s.kind == gherkin.TokenTypeComment
+	}
+	if sec.prev != nil && isAttached(sec.prev) {
+		return nil
+	}
+	for s := sec; s != nil; s = s.nex {
+		if !isAttached(s) {
+			return s
+		}
+	}
+	return nil
+}
+
+// emptyLines renders the empty lines of a section
+func (b BlankLines) emptyLines(document []string, lines []string) []string {
+	if !b.Collapse || len(lines) == 0 {
+		return lines
+	}
+	if len(document) > 0 && document[len(document)-1] == "" {
+		return []string{}
+	}
+	return lines[:1]
+}
+
+// collapseLines reduces runs of empty lines of a description to a single empty line
+func (b BlankLines) collapseLines(lines []string) []string {
+	if !b.Collapse {
+		return lines
+	}
+	collapsed := []string{}
+	for i, line := range lines {
+		if i > 0 && strings.TrimSpace(line) == "" && strings.TrimSpace(lines[i-1]) == "" {
+			continue
+		}
+		collapsed = append(collapsed, line)
+	}
+	return collapsed
+}
+
+// setBlankLines replaces the empty lines ending a document with count empty lines,
+// no empty line is added at the beginning of a document
+func setBlankLines(document []string, count int) []string {
+	for len(document) > 0 && document[len(document)-1] == "" {
+		document = document[:len(document)-1]
+	}
+	for i := 0; i < count && len(document) > 0; i++ {
+		document = append(document, "")
+	}
+	return document
+}
+
+// trimBlankLines removes empty lines at the beginning and at the end of a document
+func trimBlankLines(document []string) []string {
+	for len(document) > 0 && document[0] == "" {
+		document = document[1:]
+	}
+	for len(document) > 0 && document[len(document)-1] == "" {
+		document = document[:len(document)-1]
+	}
+	return document
+}
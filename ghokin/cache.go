@@ -0,0 +1,45 @@
+package ghokin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// cache records on disk contents known to be well formatted, an entry is keyed
+// with a hash of the content and of a key identifying the configuration
+// and the ghokin version used to format it
+type cache struct {
+	dir string
+	key string
+}
+
+func (c cache) path(content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.key))
+	h.Write([]byte{0})
+	h.Write(content)
+	sum := hex.EncodeToString(h.Sum(nil))
+	return filepath.Join(c.dir, sum[:2], sum)
+}
+
+// has checks if a content has already been recorded as well formatted
+func (c cache) has(content []byte) bool {
+	_, err := os.Stat(c.path(content))
+	return err == nil
+}
+
+// add records a content as well formatted
+func (c cache) add(content []byte) error {
+	p := c.path(content)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, []byte{}, 0o644)
+}
+
+// CleanCache removes every entry recorded in the cache folder
+func CleanCache(dir string) error {
+	return os.RemoveAll(dir)
+}
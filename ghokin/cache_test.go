@@ -0,0 +1,24 @@
+package ghokin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin-cache"))
+
+	c := cache{"/tmp/ghokin-cache", "v1"}
+	assert.False(t, c.has([]byte("content")))
+	assert.NoError(t, c.add([]byte("content")))
+	assert.True(t, c.has([]byte("content")))
+	assert.False(t, c.has([]byte("another content")))
+	assert.False(t, cache{"/tmp/ghokin-cache", "v2"}.has([]byte("content")))
+
+	assert.NoError(t, CleanCache("/tmp/ghokin-cache"))
+	assert.False(t, c.has([]byte("content")))
+	_, err := os.Stat("/tmp/ghokin-cache")
+	assert.True(t, os.IsNotExist(err))
+}
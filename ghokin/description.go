@@ -0,0 +1,105 @@
+package ghokin
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var listItemRegexp = regexp.MustCompile(`^([-*+]|\d+[.)])\s+`)
+
+// descriptionBlock is a paragraph or a list item to reflow, or a verbatim line
+type descriptionBlock struct {
+	marker   string
+	words    []string
+	verbatim *string
+}
+
+// reflowDescription rewraps description paragraphs and list items to fit in width,
+// empty lines, code blocks indented with 4 spaces or more and fenced code blocks
+// are kept as they are. Returned lines are indented relatively to the least indented line
+func reflowDescription(lines []string, width int) []string {
+	base := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			if i := indentWidth(line); base == -1 || i < base {
+				base = i
+			}
+		}
+	}
+	if base == -1 {
+		base = 0
+	}
+
+	blocks := []descriptionBlock{}
+	verbatim := func(line string) {
+		blocks = append(blocks, descriptionBlock{verbatim: &line})
+	}
+	fenced := false
+	var current *descriptionBlock
+	for _, line := range lines {
+		text := strings.TrimSpace(line)
+		relative := strings.TrimRight(removeIndent(line, base), " \t")
+		switch {
+		case strings.HasPrefix(text, "```"):
+			fenced = !fenced
+			current = nil
+			verbatim(relative)
+		case fenced, text == "", indentWidth(line)-base >= 4 && current == nil:
+			current = nil
+			verbatim(relative)
+		case listItemRegexp.MatchString(text):
+			marker := listItemRegexp.FindString(text)
+			nesting := relative[:indentWidth(relative)]
+			blocks = append(blocks, descriptionBlock{marker: nesting + strings.TrimSpace(marker) + " ", words: strings.Fields(text[len(marker):])})
+			current = &blocks[len(blocks)-1]
+		case current != nil:
+			current.words = append(current.words, strings.Fields(text)...)
+		default:
+			blocks = append(blocks, descriptionBlock{words: strings.Fields(text)})
+			current = &blocks[len(blocks)-1]
+		}
+	}
+
+	content := []string{}
+	for _, block := range blocks {
+		if block.verbatim != nil {
+			content = append(content, *block.verbatim)
+			continue
+		}
+		content = append(content, wrapWords(block.marker, block.words, width)...)
+	}
+	return content
+}
+
+// wrapWords fills lines with words up to width, the marker starts the first line
+// and following lines are indented with its length, a word longer than width is put alone on its line
+func wrapWords(marker string, words []string, width int) []string {
+	hanging := strings.Repeat(" ", utf8.RuneCountInString(marker))
+	lines := []string{}
+	line := marker
+	empty := true
+	for _, word := range words {
+		if !empty && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, line)
+			line, empty = hanging, true
+		}
+		if !empty {
+			line += " "
+		}
+		line += word
+		empty = false
+	}
+	return append(lines, line)
+}
+
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+func removeIndent(line string, indent int) string {
+	if indentWidth(line) < indent {
+		return strings.TrimLeft(line, " \t")
+	}
+	return line[indent:]
+}
@@ -0,0 +1,145 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReflowDescription(t *testing.T) {
+	type scenario struct {
+		name   string
+		lines  []string
+		width  int
+		output []string
+	}
+
+	scenarios := []scenario{
+		{
+			"Reflow paragraphs",
+			[]string{
+				"  As a user I want to",
+				"  buy a book because reading is great",
+				"",
+				"  Another paragraph",
+			},
+			20,
+			[]string{
+				"As a user I want to",
+				"buy a book because",
+				"reading is great",
+				"",
+				"Another paragraph",
+			},
+		},
+		{
+			"Reflow list items",
+			[]string{
+				"Rules :",
+				"- a first rule that is too long",
+				"  with a continuation",
+				"  * a nested rule that is long",
+				"1. an ordered item",
+			},
+			20,
+			[]string{
+				"Rules :",
+				"- a first rule that",
+				"  is too long with a",
+				"  continuation",
+				"  * a nested rule",
+				"    that is long",
+				"1. an ordered item",
+			},
+		},
+		{
+			"Keep code blocks",
+			[]string{
+				"An example :",
+				"",
+				"    curl -X POST http://localhost/books",
+				"",
+				"```",
+				"a fenced   block",
+				"```",
+				"a last paragraph",
+			},
+			20,
+			[]string{
+				"An example :",
+				"",
+				"    curl -X POST http://localhost/books",
+				"",
+				"```",
+				"a fenced   block",
+				"```",
+				"a last paragraph",
+			},
+		},
+		{
+			"Keep words longer than the width",
+			[]string{"a https://example.com/a/very/long/url b"},
+			10,
+			[]string{"a", "https://example.com/a/very/long/url", "b"},
+		},
+		{
+			"Keep empty lines",
+			[]string{"", ""},
+			10,
+			[]string{"", ""},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.output, reflowDescription(scenario.lines, scenario.width))
+		})
+	}
+}
+
+func TestFormatWithMaxLineLength(t *testing.T) {
+	content := `Feature: test
+  As a user I want to buy a book
+  because reading is great
+
+  Scenario: scenario
+    A description of the scenario that is long
+      with a continuation
+    Given a thing
+      """
+      a doc string that is not reflowed at all
+      """
+`
+
+	buf, err := format([]byte(content), 2, map[string]string{}, newOptions([]Option{WithMaxLineLength(30), WithIdempotencyCheck(true)}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `Feature: test
+  As a user I want to buy a
+  book because reading is
+  great
+
+  Scenario: scenario
+  A description of the
+  scenario that is long with a
+  continuation
+    Given a thing
+      """
+      a doc string that is not reflowed at all
+      """
+`, string(buf))
+
+	buf, err = format([]byte(content), 2, map[string]string{}, newOptions([]Option{}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, `Feature: test
+  As a user I want to buy a book
+  because reading is great
+
+  Scenario: scenario
+  A description of the scenario that is long
+  with a continuation
+    Given a thing
+      """
+      a doc string that is not reflowed at all
+      """
+`, string(buf))
+}
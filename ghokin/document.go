@@ -0,0 +1,239 @@
+package ghokin
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
+	gherkin "github.com/cucumber/gherkin/go/v28"
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// Document is a parsed feature file, lines are numbered
+// from 1 in the gherkin AST and from 0 in Lines
+type Document struct {
+	File    string
+	Lines   []string
+	Gherkin *messages.GherkinDocument
+	Dialect *gherkin.Dialect
+}
+
+// Line returns the content of a line numbered from 1,
+// an empty string is returned when the line doesn't exist
+func (d *Document) Line(number int64) string {
+	if number < 1 || int(number) > len(d.Lines) {
+		return ""
+	}
+	return d.Lines[number-1]
+}
+
+func loadDocument(file string) (*Document, error) {
+	content, err := os.ReadFile(file) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	content, err = decode(content)
+	if err != nil {
+		return nil, err
+	}
+	return parseDocument(file, content)
+}
+
+func parseDocument(file string, content []byte) (*Document, error) {
+	contentTransformer := &transformer.ContentTransformer{}
+	contentTransformer.DetectSettings(content)
+	content = contentTransformer.Prepare(content)
+	doc, err := gherkin.ParseGherkinDocument(bytes.NewReader(content), (&messages.Incrementing{}).NewId)
+	if err != nil {
+		return nil, err
+	}
+	language := gherkin.DefaultDialect
+	if doc.Feature != nil {
+		language = doc.Feature.Language
+	}
+	return &Document{
+		File:    file,
+		Lines:   strings.Split(strings.TrimSuffix(string(content), "\n"), "\n"),
+		Gherkin: doc,
+		Dialect: gherkin.DialectsBuiltin().GetDialect(language),
+	}, nil
+}
+
+// scope groups the background and the scenarios defined directly
+// in a feature or in a rule, rule is nil for the feature scope
+type scope struct {
+	rule       *messages.Rule
+	background *messages.Background
+	scenarios  []*messages.Scenario
+}
+
+// scopes returns the feature scope followed by a scope per rule
+func (d *Document) scopes() []scope {
+	if d.Gherkin.Feature == nil {
+		return []scope{}
+	}
+	scopes := []scope{{scenarios: []*messages.Scenario{}}}
+	for _, child := range d.Gherkin.Feature.Children {
+		switch {
+		case child.Background != nil:
+			scopes[0].background = child.Background
+		case child.Scenario != nil:
+			scopes[0].scenarios = append(scopes[0].scenarios, child.Scenario)
+		case child.Rule != nil:
+			s := scope{rule: child.Rule, scenarios: []*messages.Scenario{}}
+			for _, ruleChild := range child.Rule.Children {
+				switch {
+				case ruleChild.Background != nil:
+					s.background = ruleChild.Background
+				case ruleChild.Scenario != nil:
+					s.scenarios = append(s.scenarios, ruleChild.Scenario)
+				}
+			}
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// scenarios returns every scenario of the document
+func (d *Document) scenarios() []*messages.Scenario {
+	scenarios := []*messages.Scenario{}
+	for _, s := range d.scopes() {
+		scenarios = append(scenarios, s.scenarios...)
+	}
+	return scenarios
+}
+
+// elementLines returns sorted lines of backgrounds, scenarios and rules
+func (d *Document) elementLines() []int64 {
+	lines := []int64{}
+	for _, s := range d.scopes() {
+		if s.rule != nil {
+			lines = append(lines, s.rule.Location.Line)
+		}
+		if s.background != nil {
+			lines = append(lines, s.background.Location.Line)
+		}
+		for _, scenario := range s.scenarios {
+			lines = append(lines, scenario.Location.Line)
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i] < lines[j] })
+	return lines
+}
+
+// blockStart returns the first line of tags and comments written right before a line
+func (d *Document) blockStart(line int64) int64 {
+	for line > 1 {
+		previous := strings.TrimSpace(d.Line(line - 1))
+		if !strings.HasPrefix(previous, "@") && !strings.HasPrefix(previous, "#") {
+			break
+		}
+		line--
+	}
+	return line
+}
+
+// block returns the first and the last line of the element starting at a line, tags and
+// comments written right before it are included and trailing empty lines are excluded
+func (d *Document) block(line int64) (int64, int64) {
+	end := int64(len(d.Lines))
+	for _, l := range d.elementLines() {
+		if l > line {
+			end = d.blockStart(l) - 1
+			break
+		}
+	}
+	for end > line && strings.TrimSpace(d.Line(end)) == "" {
+		end--
+	}
+	return d.blockStart(line), end
+}
+
+// keyword returns the canonical keyword of a kind when one is defined, otherwise
+// the first keyword of this kind used in the document or the default one
+func (d *Document) keyword(kind string, keywords Keywords) string {
+	if keyword, ok := d.knownKeyword(kind, keywords); ok {
+		return keyword
+	}
+	return defaultKeyword(d.language(), kind)
+}
+
+// knownKeyword returns the canonical keyword of a kind when one is defined, otherwise
+// the first keyword of this kind used in the document, false is returned when there is none
+func (d *Document) knownKeyword(kind string, keywords Keywords) (string, bool) {
+	if keyword, ok := keywords[d.language()][kind]; ok {
+		return keyword, true
+	}
+	used := []string{}
+	for _, scenario := range d.scenarios() {
+		used = append(used, scenario.Keyword)
+		for _, examples := range scenario.Examples {
+			used = append(used, examples.Keyword)
+		}
+	}
+	for _, keyword := range used {
+		for _, k := range d.Dialect.Keywords[kind] {
+			if keyword == k {
+				return keyword, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (d *Document) language() string {
+	if d.Gherkin.Feature != nil {
+		return d.Gherkin.Feature.Language
+	}
+	return gherkin.DefaultDialect
+}
+
+// loadDocuments parses files or folders with a pool of jobs, documents are sorted by file
+// and errors of files that can't be parsed are sorted by file too
+func loadDocuments(paths []string, extensions []string, jobs int) ([]*Document, []error) {
+	files, results := collectFiles(paths, extensions)
+	docs := []*Document{}
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	fc := make(chan fileToProcess)
+
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+
+		go func() {
+			for file := range fc {
+				doc, err := loadDocument(file.path)
+				if err != nil && file.wrapError {
+					err = ProcessFileError{Message: err.Error(), File: file.path}
+				}
+				mu.Lock()
+				if err != nil {
+					results = append(results, ProcessFileResult{File: file.path, Status: StatusError, Err: err})
+				} else {
+					docs = append(docs, doc)
+				}
+				mu.Unlock()
+			}
+			wg.Done()
+		}()
+	}
+
+	for _, file := range files {
+		fc <- file
+	}
+
+	close(fc)
+	wg.Wait()
+
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].File < docs[j].File
+	})
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].File < results[j].File
+	})
+	return docs, results.Errors()
+}
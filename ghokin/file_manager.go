@@ -7,9 +7,10 @@ import (
 	"os"
 	mpath "path"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
-	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
 	"github.com/saintfish/chardet"
 	"golang.org/x/net/html/charset"
 )
@@ -31,116 +32,229 @@ type aliases map[string]string
 type FileManager struct {
 	indent  int
 	aliases aliases
+	options options
 }
 
 // NewFileManager creates a brand new FileManager, it requires indentation values and aliases defined
-// as a shell commands in comments
-func NewFileManager(indent int, aliases map[string]string) FileManager {
+// as a shell commands in comments, optional behaviours are enabled through options
+func NewFileManager(indent int, aliases map[string]string, opts ...Option) FileManager {
 	return FileManager{
 		indent,
 		aliases,
+		newOptions(opts),
 	}
 }
 
+// Status defines the outcome of processing a file
+type Status int
+
+const (
+	// StatusOK is set when a file was processed successfully
+	StatusOK Status = iota
+	// StatusUnformatted is set when a checked file is not properly formatted
+	StatusUnformatted
+	// StatusError is set when an error occurred while processing a file
+	StatusError
+	// StatusSkipped is set when a file is known from the cache to be well formatted
+	StatusSkipped
+)
+
+// String returns a human readable status
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusUnformatted:
+		return "unformatted"
+	case StatusSkipped:
+		return "skipped"
+	default:
+		return "error"
+	}
+}
+
+// ProcessFileResult stores the outcome of processing a file, Changed is true
+// when formatting the file produces a content different from the original one,
+// Diff is only defined for dry runs
+type ProcessFileResult struct {
+	File     string
+	Status   Status
+	Changed  bool
+	Diff     string
+	Err      error
+	Duration time.Duration
+}
+
+// ProcessFileResults is a list of results sorted by file path
+type ProcessFileResults []ProcessFileResult
+
+// Errors returns all errors that occurred, in file path order
+func (p ProcessFileResults) Errors() []error {
+	errors := []error{}
+	for _, result := range p {
+		if result.Err != nil {
+			errors = append(errors, result.Err)
+		}
+	}
+	return errors
+}
+
+type processFunc func(file string, content []byte, changed bool) (Status, error)
+
+type transformFunc func(file string, content []byte) ([]byte, error)
+
 // Transform formats and applies shell commands on feature file
 func (f FileManager) Transform(filename string) ([]byte, error) {
-	content, err := os.ReadFile(filename)
+	_, content, err := f.transformFile(filename)
+	return content, err
+}
+
+// transformFile returns the original content of the file alongside its formatted version
+func (f FileManager) transformFile(filename string) ([]byte, []byte, error) {
+	original, err := os.ReadFile(filename)
+	if err != nil {
+		return []byte{}, []byte{}, err
+	}
+	content, err := f.transformContent(filename, original)
+	return original, content, err
+}
+
+func (f FileManager) transformContent(_ string, content []byte) ([]byte, error) {
+	content, err := decode(content)
 	if err != nil {
 		return []byte{}, err
 	}
+	return format(content, f.indent, f.aliases, f.options)
+}
+
+// decode converts a content to UTF-8 when another charset is detected
+func decode(content []byte) ([]byte, error) {
 	detector := chardet.NewTextDetector()
 	result, err := detector.DetectBest(content)
 	if err != nil {
 		return []byte{}, err
 	}
-	if result.Charset != "UTF-8" {
-		r, err := charset.NewReaderLabel(result.Charset, bytes.NewBuffer(content))
-		if err != nil {
-			return []byte{}, err
-		}
-		content, err = io.ReadAll(r)
-		if err != nil {
-			return []byte{}, err
-		}
+	if result.Charset == "UTF-8" {
+		return content, nil
 	}
-	contentTransformer := &transformer.ContentTransformer{}
-	contentTransformer.DetectSettings(content)
-	content = contentTransformer.Prepare(content)
-	section, err := extractSections(content)
+	r, err := charset.NewReaderLabel(result.Charset, bytes.NewBuffer(content))
 	if err != nil {
 		return []byte{}, err
 	}
-	content, err = transform(section, f.indent, f.aliases)
-	if err != nil {
-		return []byte{}, err
-	}
-	return contentTransformer.Restore(content), nil
+	return io.ReadAll(r)
 }
 
-// TransformAndReplace formats and applies shell commands on file or folder
+// TransformAndReplace formats and applies shell commands on files or folders
 // and replace the content of files
-func (f FileManager) TransformAndReplace(path string, extensions []string) []error {
-	return f.process(path, extensions, replaceFileWithContent)
+func (f FileManager) TransformAndReplace(paths []string, extensions []string) ProcessFileResults {
+	return f.process(paths, extensions, f.transformContent, replaceFileWithContent)
 }
 
-// Check ensures file or folder is well formatted
-func (f FileManager) Check(path string, extensions []string) []error {
-	return f.process(path, extensions, check)
+// Check ensures files or folders are well formatted
+func (f FileManager) Check(paths []string, extensions []string) ProcessFileResults {
+	return f.process(paths, extensions, f.transformContent, check)
 }
 
-func (f FileManager) process(path string, extensions []string, processFile func(file string, content []byte) error) []error {
-	errors := []error{}
-	fi, err := os.Stat(path)
-	if err != nil {
-		return append(errors, err)
+// Fix applies fixes of issues reported by the linter on files or folders, fixed contents are
+// formatted and replace the content of files, files without fix are left untouched and
+// the cache is never used to skip files
+func (f FileManager) Fix(linter Linter, paths []string, extensions []string) ProcessFileResults {
+	f.options.cache = nil
+	return f.process(paths, extensions, func(file string, content []byte) ([]byte, error) {
+		decoded, err := decode(content)
+		if err != nil {
+			return []byte{}, err
+		}
+		fixed, changed, err := linter.fix(file, decoded)
+		if err != nil || !changed {
+			return content, err
+		}
+		return f.transformContent(file, fixed)
+	}, replaceFileWithContent)
+}
+
+// Translate rewrites keywords of files or folders in the dialect of a language, adds
+// or updates the language header and replace the content of files
+func (f FileManager) Translate(language string, paths []string, extensions []string) (ProcessFileResults, error) {
+	language = findLanguage(language)
+	if err := checkLanguage(language); err != nil {
+		return ProcessFileResults{}, err
+	}
+	f.options.language = language
+	f.options.cache = nil
+	return f.process(paths, extensions, f.transformContent, replaceFileWithContent), nil
+}
+
+type fileToProcess struct {
+	path      string
+	wrapError bool
+}
+
+func (f FileManager) process(paths []string, extensions []string, transformContent transformFunc, processFile processFunc) ProcessFileResults {
+	files, results := collectFiles(paths, extensions)
+	results = append(results, f.processFiles(files, transformContent, processFile)...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].File < results[j].File
+	})
+	return results
+}
+
+// collectFiles finds all feature files from a list of files and folders, duplicates are removed,
+// errors are returned as results for paths that can't be explored
+func collectFiles(paths []string, extensions []string) ([]fileToProcess, ProcessFileResults) {
+	results := ProcessFileResults{}
+	files := []fileToProcess{}
+	seen := map[string]bool{}
+	addFile := func(file string, wrapError bool) {
+		if key := filepath.Clean(file); !seen[key] {
+			seen[key] = true
+			files = append(files, fileToProcess{file, wrapError})
+		}
 	}
 
-	switch mode := fi.Mode(); {
-	case mode.IsDir():
-		errors = append(errors, f.processPath(path, extensions, processFile)...)
-	case mode.IsRegular():
-		b, err := f.Transform(path)
+	for _, path := range paths {
+		fi, err := os.Stat(path)
 		if err != nil {
-			return append(errors, err)
+			results = append(results, ProcessFileResult{File: path, Status: StatusError, Err: err})
+			continue
 		}
-		if err := processFile(path, b); err != nil {
-			errors = append(errors, err)
+
+		switch mode := fi.Mode(); {
+		case mode.IsDir():
+			fs, err := findFeatureFiles(path, extensions)
+			if err != nil {
+				results = append(results, ProcessFileResult{File: path, Status: StatusError, Err: err})
+				continue
+			}
+			for _, file := range fs {
+				addFile(file, true)
+			}
+		case mode.IsRegular():
+			addFile(path, len(paths) > 1)
 		}
 	}
-	return errors
+	return files, results
 }
 
-func (f FileManager) processPath(path string, extensions []string, processFile func(file string, content []byte) error) []error {
-	errors := []error{}
-	fc := make(chan string)
+func (f FileManager) processFiles(files []fileToProcess, transformContent transformFunc, processFile processFunc) ProcessFileResults {
+	results := ProcessFileResults{}
+	fc := make(chan fileToProcess)
 	wg := sync.WaitGroup{}
 	var mu sync.Mutex
 
-	files, err := findFeatureFiles(path, extensions)
-	if err != nil {
-		return []error{err}
-	}
 	if len(files) == 0 {
-		return []error{}
+		return results
 	}
 
-	for i := 0; i < 10; i++ {
+	for i := 0; i < f.options.jobs; i++ {
 		wg.Add(1)
 
 		go func() {
 			for file := range fc {
-				b, err := f.Transform(file)
-				if err != nil {
-					mu.Lock()
-					errors = append(errors, ProcessFileError{Message: err.Error(), File: file})
-					mu.Unlock()
-					continue
-				}
-				if err := processFile(file, b); err != nil {
-					mu.Lock()
-					errors = append(errors, err)
-					mu.Unlock()
-				}
+				result := f.processFile(file.path, transformContent, processFile, file.wrapError)
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
 			}
 			wg.Done()
 		}()
@@ -153,32 +267,65 @@ func (f FileManager) processPath(path string, extensions []string, processFile f
 	close(fc)
 	wg.Wait()
 
-	return errors
+	return results
 }
 
-func replaceFileWithContent(file string, content []byte) error {
-	f, err := os.Create(file)
+// processFile transforms a file and applies the process function on the result,
+// when wrapError is true transformation errors are wrapped in a ProcessFileError
+func (f FileManager) processFile(file string, transformContent transformFunc, processFile processFunc, wrapError bool) ProcessFileResult {
+	start := time.Now()
+	result := f.processFileContent(file, transformContent, processFile, wrapError)
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (f FileManager) processFileContent(file string, transformContent transformFunc, processFile processFunc, wrapError bool) ProcessFileResult {
+	wrap := func(err error) error {
+		if wrapError {
+			return ProcessFileError{Message: err.Error(), File: file}
+		}
+		return err
+	}
+	original, err := os.ReadFile(file)
 	if err != nil {
-		return ProcessFileError{Message: err.Error(), File: file}
+		return ProcessFileResult{File: file, Status: StatusError, Err: wrap(err)}
 	}
-	_, err = f.Write(content)
+	if f.options.cache != nil && f.options.cache.has(original) {
+		return ProcessFileResult{File: file, Status: StatusSkipped}
+	}
+	content, err := transformContent(file, original)
 	if err != nil {
-		return ProcessFileError{Message: err.Error(), File: file}
+		return ProcessFileResult{File: file, Status: StatusError, Err: wrap(err)}
+	}
+	result := ProcessFileResult{File: file, Changed: !bytes.Equal(original, content)}
+	if f.options.dryRun {
+		result.Diff = unifiedDiff(file, file, original, content)
+		return result
+	}
+	result.Status, result.Err = processFile(file, content, result.Changed)
+	if result.Status == StatusOK && f.options.cache != nil {
+		if err := f.options.cache.add(content); err != nil {
+			result.Status, result.Err = StatusError, ProcessFileError{Message: err.Error(), File: file}
+		}
 	}
-	return nil
+	return result
 }
 
-func check(file string, content []byte) error {
-	currentContent, err := os.ReadFile(file) // #nosec
-	if err != nil {
-		return ProcessFileError{Message: err.Error(), File: file}
+func replaceFileWithContent(file string, content []byte, changed bool) (Status, error) {
+	if !changed {
+		return StatusOK, nil
 	}
-
-	if !bytes.Equal(currentContent, content) {
-		return ProcessFileError{Message: "file is not properly formatted", File: file}
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		return StatusError, ProcessFileError{Message: err.Error(), File: file}
 	}
+	return StatusOK, nil
+}
 
-	return nil
+func check(file string, content []byte, changed bool) (Status, error) {
+	if changed {
+		return StatusUnformatted, ProcessFileError{Message: "file is not properly formatted", File: file}
+	}
+	return StatusOK, nil
 }
 
 func findFeatureFiles(rootPath string, extensions []string) ([]string, error) {
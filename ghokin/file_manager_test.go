@@ -367,7 +367,7 @@ hello world
 					"seq": "seq 1 3",
 				},
 			)
-			scenario.test(f.TransformAndReplace(scenario.path, scenario.extensions))
+			scenario.test(f.TransformAndReplace([]string{scenario.path}, scenario.extensions).Errors())
 		})
 	}
 }
@@ -658,7 +658,108 @@ hello world
 				},
 			)
 
-			scenario.test(f.Check(scenario.path, scenario.extensions))
+			scenario.test(f.Check([]string{scenario.path}, scenario.extensions).Errors())
 		})
 	}
 }
+
+func TestFileManagerCheckResults(t *testing.T) {
+	formatted := "Feature: test\n\n  Scenario: scenario\n    Given whatever\n"
+	unformatted := "Feature: test\nScenario: scenario\nGiven whatever\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin/b", 0o777))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin/a", 0o777))
+
+	for file, content := range map[string]string{
+		"/tmp/ghokin/c.feature":   formatted,
+		"/tmp/ghokin/b/a.feature": unformatted,
+		"/tmp/ghokin/a/z.feature": "whatever",
+		"/tmp/ghokin/a/b.feature": formatted,
+	} {
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0o777))
+	}
+
+	for i := 0; i < 5; i++ {
+		results := NewFileManager(2, map[string]string{}, WithJobs(4)).Check([]string{"/tmp/ghokin"}, []string{"feature"})
+
+		assert.Len(t, results, 4)
+		for j, expected := range []struct {
+			file    string
+			status  Status
+			changed bool
+		}{
+			{"/tmp/ghokin/a/b.feature", StatusOK, false},
+			{"/tmp/ghokin/a/z.feature", StatusError, false},
+			{"/tmp/ghokin/b/a.feature", StatusUnformatted, true},
+			{"/tmp/ghokin/c.feature", StatusOK, false},
+		} {
+			assert.Equal(t, expected.file, results[j].File)
+			assert.Equal(t, expected.status, results[j].Status)
+			assert.Equal(t, expected.changed, results[j].Changed)
+		}
+		assert.NoError(t, results[0].Err)
+		assert.Error(t, results[1].Err)
+		assert.EqualError(t, results[2].Err, `an error occurred with file "/tmp/ghokin/b/a.feature" : file is not properly formatted`)
+		assert.Len(t, results.Errors(), 2)
+	}
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "ok", StatusOK.String())
+	assert.Equal(t, "unformatted", StatusUnformatted.String())
+	assert.Equal(t, "error", StatusError.String())
+	assert.Equal(t, "skipped", StatusSkipped.String())
+}
+
+func TestFileManagerCheckSeveralPaths(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin/test1", 0o777))
+
+	for _, file := range []string{"/tmp/ghokin/file1.feature", "/tmp/ghokin/test1/file2.feature", "/tmp/ghokin/test1/file3.feature"} {
+		assert.NoError(t, os.WriteFile(file, []byte("Feature: test\nScenario: scenario\nGiven whatever\n"), 0o777))
+	}
+
+	results := NewFileManager(2, map[string]string{}).Check(
+		[]string{"/tmp/ghokin/test1/file3.feature", "/tmp/ghokin/test1", "/tmp/ghokin/file1.feature", "/tmp/ghokin/./file1.feature", "/tmp/ghokin/whatever"},
+		[]string{"feature"},
+	)
+
+	assert.Len(t, results, 4)
+	for i, file := range []string{"/tmp/ghokin/file1.feature", "/tmp/ghokin/test1/file2.feature", "/tmp/ghokin/test1/file3.feature", "/tmp/ghokin/whatever"} {
+		assert.Equal(t, file, results[i].File)
+	}
+	assert.EqualError(t, results[0].Err, `an error occurred with file "/tmp/ghokin/file1.feature" : file is not properly formatted`)
+	assert.EqualError(t, results[3].Err, "stat /tmp/ghokin/whatever: no such file or directory")
+}
+
+func TestFileManagerWithCache(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin-cache"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file1.feature", []byte("Feature: test\nScenario: scenario\nGiven whatever\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/file2.feature", []byte("Feature: test\n  Scenario: scenario\n    Given whatever\n"), 0o777))
+
+	f := NewFileManager(2, map[string]string{}, WithCache("/tmp/ghokin-cache", "key"))
+
+	statuses := func(results ProcessFileResults) []Status {
+		s := []Status{}
+		for _, result := range results {
+			s = append(s, result.Status)
+		}
+		return s
+	}
+
+	results := f.Check([]string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Equal(t, []Status{StatusUnformatted, StatusOK}, statuses(results))
+	results = f.Check([]string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Equal(t, []Status{StatusUnformatted, StatusSkipped}, statuses(results))
+	results = f.TransformAndReplace([]string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Equal(t, []Status{StatusOK, StatusSkipped}, statuses(results))
+	assert.True(t, results[0].Changed)
+	results = f.Check([]string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Equal(t, []Status{StatusSkipped, StatusSkipped}, statuses(results))
+
+	results = NewFileManager(4, map[string]string{}, WithCache("/tmp/ghokin-cache", "another key")).Check([]string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Equal(t, []Status{StatusUnformatted, StatusUnformatted}, statuses(results))
+}
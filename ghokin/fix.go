@@ -0,0 +1,158 @@
+package ghokin
+
+import (
+	"strings"
+
+	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// maxFixPasses limits how many times a document is linted and fixed,
+// fixes editing the same lines are applied in different passes
+const maxFixPasses = 10
+
+// Fix is a safe change solving an issue, rules only attach a fix
+// to an issue when applying it can't change the meaning of a document
+type Fix struct {
+	Edits []LineEdit
+}
+
+// LineEdit replaces the content of a line numbered from 1, Text can contain
+// several lines separated with a new line, the line is removed when Delete is true
+type LineEdit struct {
+	Line   int
+	Text   string
+	Delete bool
+}
+
+// fix lints a content and applies fixes of the issues found until no fix is left,
+// false is returned when no fix was applied
+func (l Linter) fix(file string, content []byte) ([]byte, bool, error) {
+	contentTransformer := &transformer.ContentTransformer{}
+	contentTransformer.DetectSettings(content)
+	content = contentTransformer.Prepare(content)
+	changed := false
+	for i := 0; i < maxFixPasses; i++ {
+		doc, err := parseDocument(file, content)
+		if err != nil {
+			return []byte{}, false, err
+		}
+		lines, applied := applyFixes(doc.Lines, l.lintDocument(doc))
+		if applied == 0 {
+			break
+		}
+		changed = true
+		content = []byte(strings.Join(lines, "\n") + "\n")
+	}
+	return contentTransformer.Restore(content), changed, nil
+}
+
+// applyFixes applies fixes in the order of issues, a fix editing a line
+// already edited by a previous fix is skipped
+func applyFixes(lines []string, issues []Issue) ([]string, int) {
+	edited := map[int]bool{}
+	edits := []LineEdit{}
+	applied := 0
+	for _, issue := range issues {
+		if issue.Fix == nil || !canApplyFix(issue.Fix, edited, len(lines)) {
+			continue
+		}
+		for _, edit := range issue.Fix.Edits {
+			edited[edit.Line] = true
+		}
+		edits = append(edits, issue.Fix.Edits...)
+		applied++
+	}
+	return applyEdits(lines, edits), applied
+}
+
+// applyEdits replaces or removes lines, edits on lines that don't exist are ignored
+func applyEdits(lines []string, edits []LineEdit) []string {
+	replaced := map[int]LineEdit{}
+	for _, edit := range edits {
+		replaced[edit.Line] = edit
+	}
+	editedLines := []string{}
+	for i, line := range lines {
+		edit, ok := replaced[i+1]
+		switch {
+		case !ok:
+			editedLines = append(editedLines, line)
+		case !edit.Delete:
+			editedLines = append(editedLines, edit.Text)
+		}
+	}
+	return editedLines
+}
+
+func canApplyFix(fix *Fix, edited map[int]bool, lineCount int) bool {
+	for _, edit := range fix.Edits {
+		if edit.Line < 1 || edit.Line > lineCount || edited[edit.Line] {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceTagFix replaces a tag in its line, the tag and the spaces following it
+// are removed when replacement is empty, nil is returned if the tag can't be found
+func replaceTagFix(doc *Document, tag *messages.Tag, replacement string) *Fix {
+	line, ok := replaceTag([]rune(doc.Line(tag.Location.Line)), tag, replacement)
+	if !ok {
+		return nil
+	}
+	return &Fix{[]LineEdit{tagLineEdit(int(tag.Location.Line), string(line))}}
+}
+
+// replaceTag replaces a tag in its line, false is returned
+// when the tag is not found at its location
+func replaceTag(line []rune, tag *messages.Tag, replacement string) ([]rune, bool) {
+	name := []rune(tag.Name)
+	start := int(tag.Location.Column) - 1
+	end := start + len(name)
+	if start < 0 || end > len(line) || string(line[start:end]) != tag.Name {
+		return nil, false
+	}
+	if replacement == "" {
+		for end < len(line) && (line[end] == ' ' || line[end] == '\t') {
+			end++
+		}
+	}
+	return append(append(append([]rune{}, line[:start]...), []rune(replacement)...), line[end:]...), true
+}
+
+// tagLineEdit replaces a tag line, the line is deleted when no tag remains
+func tagLineEdit(number int, text string) LineEdit {
+	if strings.TrimSpace(text) == "" {
+		return LineEdit{Line: number, Delete: true}
+	}
+	return LineEdit{Line: number, Text: strings.TrimRight(text, " \t")}
+}
+
+// removeColumnFix removes a column from every row of a table,
+// nil is returned when the column is the only one of the table
+func removeColumnFix(doc *Document, rows []*messages.TableRow, column int) *Fix {
+	fix := &Fix{}
+	for _, row := range rows {
+		if len(row.Cells) < 2 || column >= len(row.Cells) {
+			return nil
+		}
+		line := doc.Line(row.Location.Line)
+		cells := []string{}
+		for i, cell := range row.Cells {
+			if i != column {
+				cells = append(cells, escapeCell(cell.Value))
+			}
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		fix.Edits = append(fix.Edits, LineEdit{
+			Line: int(row.Location.Line),
+			Text: indent + "| " + strings.Join(cells, " | ") + " |",
+		})
+	}
+	return fix
+}
+
+func escapeCell(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", `\n`).Replace(value)
+}
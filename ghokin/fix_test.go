@@ -0,0 +1,159 @@
+package ghokin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyFixes(t *testing.T) {
+	type scenario struct {
+		testName string
+		issues   []Issue
+		lines    []string
+		applied  int
+	}
+
+	scenarios := []scenario{
+		{
+			"No fixes",
+			[]Issue{{Line: 1}},
+			[]string{"a", "b", "c"},
+			0,
+		},
+		{
+			"Replace and delete lines",
+			[]Issue{
+				{Fix: &Fix{[]LineEdit{{Line: 1, Text: "A"}}}},
+				{Fix: &Fix{[]LineEdit{{Line: 2, Delete: true}, {Line: 3, Text: "C"}}}},
+			},
+			[]string{"A", "C"},
+			2,
+		},
+		{
+			"Skip fixes editing a line already edited",
+			[]Issue{
+				{Fix: &Fix{[]LineEdit{{Line: 1, Text: "A"}}}},
+				{Fix: &Fix{[]LineEdit{{Line: 3, Text: "C"}, {Line: 1, Text: "AA"}}}},
+				{Fix: &Fix{[]LineEdit{{Line: 4, Text: "D"}}}},
+			},
+			[]string{"A", "b", "c"},
+			1,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.testName, func(t *testing.T) {
+			lines, applied := applyFixes([]string{"a", "b", "c"}, scenario.issues)
+			assert.Equal(t, scenario.lines, lines)
+			assert.Equal(t, scenario.applied, applied)
+		})
+	}
+}
+
+func TestFileManagerFix(t *testing.T) {
+	content := "@smoke @Billing\r\n" +
+		"Feature: Fix\r\n" +
+		"\r\n" +
+		"  @wip @WIP @smoke\r\n" +
+		"  Scenario Outline: An outline\r\n" +
+		"    Given a <thing>\r\n" +
+		"\r\n" +
+		"    Examples:\r\n" +
+		"      | thing | unused | a\\|b |\r\n" +
+		"      | book  | 1      | 2    |\r\n" +
+		"\r\n" +
+		"  @smoke\r\n" +
+		"  Scenario: A scenario\r\n" +
+		"    When   a thing\r\n" +
+		"    Given  another thing\r\n"
+
+	fixed := "@smoke @billing\r\n" +
+		"Feature: Fix\r\n" +
+		"\r\n" +
+		"  @wip\r\n" +
+		"  Scenario Outline: An outline\r\n" +
+		"    Given a <thing>\r\n" +
+		"\r\n" +
+		"    Examples:\r\n" +
+		"      | thing |\r\n" +
+		"      | book  |\r\n" +
+		"\r\n" +
+		"  Scenario: A scenario\r\n" +
+		"    When a thing\r\n" +
+		"    Given another thing\r\n"
+
+	linter, err := NewLinter(RegisteredRules(), map[string]map[string]interface{}{
+		"tag-naming": {"pattern": "^@[a-z]+$"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/fix.feature", []byte(content), 0o777))
+
+	results := NewFileManager(2, map[string]string{}, WithDryRun(true)).Fix(linter, []string{"/tmp/ghokin/fix.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.True(t, results[0].Changed)
+	assert.Equal(t, unifiedDiff("/tmp/ghokin/fix.feature", "/tmp/ghokin/fix.feature", []byte(content), []byte(fixed)), results[0].Diff)
+	b, err := os.ReadFile("/tmp/ghokin/fix.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+
+	results = NewFileManager(2, map[string]string{}).Fix(linter, []string{"/tmp/ghokin/fix.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, StatusOK, results[0].Status)
+	assert.Empty(t, results[0].Diff)
+	b, err = os.ReadFile("/tmp/ghokin/fix.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, fixed, string(b))
+
+	assert.Equal(t, []string{
+		"/tmp/ghokin/fix.feature:14:5: warning: \"Given\" step comes after a \"When\" step at line 13 [step-order]",
+	}, func() []string {
+		issues := []string{}
+		for _, issue := range linter.Lint([]string{"/tmp/ghokin/fix.feature"}, []string{"feature"}).Issues {
+			issues = append(issues, issue.String())
+		}
+		return issues
+	}())
+}
+
+func TestFileManagerFixWithoutFix(t *testing.T) {
+	content := "Feature: Fix\n" +
+		"Scenario: A scenario\n" +
+		"Given a thing\n"
+
+	linter, err := NewLinter(RegisteredRules(), map[string]map[string]interface{}{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/fix.feature", []byte(content), 0o777))
+
+	results := NewFileManager(2, map[string]string{}, WithDryRun(true)).Fix(linter, []string{"/tmp/ghokin/fix.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.False(t, results[0].Changed)
+	assert.Empty(t, results[0].Diff)
+
+	results = NewFileManager(2, map[string]string{}).Fix(linter, []string{"/tmp/ghokin/fix.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err := os.ReadFile("/tmp/ghokin/fix.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+}
+
+func TestFileManagerFixWithInvalidFile(t *testing.T) {
+	linter, err := NewLinter(RegisteredRules(), map[string]map[string]interface{}{})
+	assert.NoError(t, err)
+
+	results := NewFileManager(2, map[string]string{}).Fix(linter, []string{"fixtures/invalid.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusError, results[0].Status)
+	assert.Error(t, results[0].Err)
+}
@@ -0,0 +1,5 @@
+# ghokin:disable=scenario-name
+Feature: Disable directive for the whole file
+
+  Scenario: TODO scenario
+    Given a TODO step
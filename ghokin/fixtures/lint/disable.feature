@@ -0,0 +1,15 @@
+Feature: Disable directives
+
+  Scenario: A scenario
+    # ghokin:disable=step-text
+    Given a TODO step
+    Given another TODO step
+
+  # ghokin:disable
+  @tag
+  Scenario: TODO scenario fully disabled
+    Given a step
+
+  # ghokin:disable=whatever
+  Scenario: TODO scenario
+    Given a step
@@ -0,0 +1,25 @@
+Feature: A feature
+
+  Scenario: A scenario
+    Given a thing
+
+  Scenario: Another scenario
+    Given a thing
+
+  Scenario Outline: A scenario
+    Given a <thing>
+
+    Examples:
+      | thing |
+      | test  |
+
+  Rule: A rule
+
+    Scenario: Another scenario
+      Given a thing
+
+    Scenario: A rule scenario
+      Given a thing
+
+    Scenario: A rule scenario
+      Given a thing
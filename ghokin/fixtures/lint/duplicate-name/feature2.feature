@@ -0,0 +1,4 @@
+Feature: A feature
+
+  Scenario: A scenario
+    Given a thing
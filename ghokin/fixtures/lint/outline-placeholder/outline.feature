@@ -0,0 +1,29 @@
+Feature: Outlines
+
+  Scenario Outline: Buy <count> <item>
+    Given I have <money> in my <wallet>
+    When I buy <count> <item>
+      | item   | price   |
+      | <item> | <price> |
+    Then I receive
+      """
+      <count> <items>
+      """
+
+    Examples: First
+      | count | item  | money | price | unused |
+      | 1     | apple | 10    | 5     | x      |
+
+    Examples: Second
+      | item  | count | money | price | unused |
+      | apple | 2     | 10    | 5     | y      |
+
+    Examples: Third
+      | count | item  | money |
+      | 3     | apple | 10    |
+
+  Scenario: Not an outline
+    Given a <thing>
+
+  Scenario Outline: Without examples
+    Given a <thing>
@@ -0,0 +1,3 @@
+Feature: Empty
+
+  Rule: Empty rule
@@ -0,0 +1,9 @@
+# language: fr
+Fonctionnalité: Structure
+
+  Scénario: Un scénario dans le désordre
+    Quand une action
+    Soit un contexte
+    Et un autre contexte
+    Alors un résultat
+    Et un autre résultat
@@ -0,0 +1,30 @@
+Feature: Structure
+
+  Background:
+    Given a context
+    And a second context
+    When an action
+    But a third context
+
+  Scenario: An empty scenario
+
+  Scenario: A scenario in order
+    Given a context
+    And another context
+    When an action
+    Then an outcome
+    But not another outcome
+
+  Scenario: A scenario out of order
+    When an action
+    Given a context
+    Then an outcome
+    And another outcome
+    When another action
+    * a step
+
+  Scenario: A long scenario
+    Given a step
+    And a step
+    And a step
+    And a step
@@ -0,0 +1,22 @@
+@smoke @Billing
+Feature: Tags
+
+  @smok @wip @wip
+  Scenario: A scenario
+    Given a thing
+
+  @smoke
+  Scenario Outline: An outline
+    Given a <thing>
+
+    @slow @slow_Run
+    Examples:
+      | thing |
+      | book  |
+
+  @fast
+  Rule: A rule
+
+    @fast @smoke
+    Scenario: A scenario in a rule
+      Given a thing
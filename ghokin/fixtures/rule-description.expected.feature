@@ -0,0 +1,14 @@
+Feature: A feature
+
+  Rule: A rule
+  A rule description
+  on two lines
+
+    Scenario: A scenario
+      Given a thing
+
+  Rule: Another rule
+
+  Another description
+    Scenario: Another scenario
+      Given a thing
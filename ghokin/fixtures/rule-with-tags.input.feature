@@ -0,0 +1,15 @@
+@feature
+Feature: Test Rule
+
+@rule
+Rule: First rule
+
+@scenario
+# A comment
+Scenario Outline: Whatever
+Given a <thing>
+
+@examples
+Examples:
+| thing |
+| book |
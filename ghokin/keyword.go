@@ -0,0 +1,190 @@
+package ghokin
+
+import (
+	"fmt"
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v28"
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// keywordKinds lists per token type the kinds of keywords, as defined
+// in gherkin dialects, a token can use
+var keywordKinds = map[gherkin.TokenType][]string{
+	gherkin.TokenTypeFeatureLine:    {"feature"},
+	gherkin.TokenTypeRuleLine:       {"rule"},
+	gherkin.TokenTypeBackgroundLine: {"background"},
+	gherkin.TokenTypeScenarioLine:   {"scenarioOutline", "scenario"},
+	gherkin.TokenTypeExamplesLine:   {"examples"},
+	gherkin.TokenTypeStepLine:       {"given", "when", "then", "and", "but"},
+}
+
+// stepKeywordKinds maps step types to keyword kinds
+var stepKeywordKinds = map[messages.StepKeywordType]string{
+	messages.StepKeywordType_CONTEXT:     "given",
+	messages.StepKeywordType_ACTION:      "when",
+	messages.StepKeywordType_OUTCOME:     "then",
+	messages.StepKeywordType_CONJUNCTION: "and",
+}
+
+// keywordKind finds the kind of the keyword of a token in its dialect, an empty
+// string is returned when the keyword is unknown or when it's the * step keyword
+func keywordKind(token *gherkin.Token) string {
+	dialect := gherkin.DialectsBuiltin().GetDialect(token.GherkinDialect)
+	if dialect == nil || token.Keyword == "* " {
+		return ""
+	}
+	for _, kind := range keywordKinds[token.Type] {
+		for _, keyword := range dialect.Keywords[kind] {
+			if keyword == token.Keyword {
+				return kind
+			}
+		}
+	}
+	return ""
+}
+
+// defaultKeyword returns the first keyword of a kind listed in a dialect,
+// an empty string is returned when no keyword exists
+func defaultKeyword(language string, kind string) string {
+	dialect := gherkin.DialectsBuiltin().GetDialect(language)
+	if dialect == nil {
+		return ""
+	}
+	for _, keyword := range dialect.Keywords[kind] {
+		if keyword != "* " {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// checkLanguage ensures a dialect exists for a language
+func checkLanguage(language string) error {
+	if gherkin.DialectsBuiltin().GetDialect(language) == nil {
+		return fmt.Errorf(`language "%s" doesn't exist`, language)
+	}
+	return nil
+}
+
+// translateTokens returns copies of tokens with keywords and language
+// headers rewritten in the dialect of a language
+func translateTokens(tokens []*gherkin.Token, language string, keywords Keywords) []*gherkin.Token {
+	translated := []*gherkin.Token{}
+	for _, token := range tokens {
+		t := *token
+		if t.Type == gherkin.TokenTypeLanguage {
+			t.Text = language
+		} else if kind := keywordKind(token); kind != "" {
+			if keyword := translateKeyword(token, kind, language, keywords); keyword != "" {
+				t.Keyword = keyword
+			}
+		}
+		t.GherkinDialect = language
+		translated = append(translated, &t)
+	}
+	return translated
+}
+
+// translateKeyword returns the canonical keyword of a kind in a language when one is defined,
+// a keyword already listed for its kind in the dialect of the language is kept, otherwise
+// the keyword at the same position in the dialect is used before the first one
+func translateKeyword(token *gherkin.Token, kind string, language string, keywords Keywords) string {
+	if keyword, ok := keywords[language][kind]; ok {
+		return keyword
+	}
+	dialect := gherkin.DialectsBuiltin().GetDialect(language)
+	if dialect == nil {
+		return ""
+	}
+	for _, keyword := range dialect.Keywords[kind] {
+		if keyword == token.Keyword {
+			return keyword
+		}
+	}
+	for i, keyword := range gherkin.DialectsBuiltin().GetDialect(token.GherkinDialect).Keywords[kind] {
+		if keyword == token.Keyword && i < len(dialect.Keywords[kind]) && dialect.Keywords[kind][i] != "* " {
+			return dialect.Keywords[kind][i]
+		}
+	}
+	return defaultKeyword(language, kind)
+}
+
+// Keywords defines per language the canonical keyword of each kind of keyword
+type Keywords map[string]map[string]string
+
+// ParseKeywords checks canonical keywords defined per language and per kind, kinds are
+// the ones of gherkin dialects : feature, rule, background, scenario, scenarioOutline,
+// examples, given, when, then, and, but. Kinds are case insensitive and trailing
+// spaces of step keywords are optional
+func ParseKeywords(config map[string]map[string]string) (Keywords, error) {
+	keywords := Keywords{}
+	for l, kinds := range config {
+		language := findLanguage(l)
+		dialect := gherkin.DialectsBuiltin().GetDialect(language)
+		if dialect == nil {
+			return Keywords{}, fmt.Errorf(`language "%s" doesn't exist`, l)
+		}
+		keywords[language] = map[string]string{}
+		for k, keyword := range kinds {
+			kind := ""
+			for dialectKind := range dialect.Keywords {
+				if strings.EqualFold(dialectKind, k) {
+					kind = dialectKind
+				}
+			}
+			if kind == "" {
+				return Keywords{}, fmt.Errorf(`keyword kind "%s" doesn't exist, it must be one of feature, rule, background, scenario, scenarioOutline, examples, given, when, then, and or but`, k)
+			}
+			canonical := ""
+			for _, dialectKeyword := range dialect.Keywords[kind] {
+				if dialectKeyword != "* " && strings.TrimSpace(dialectKeyword) == strings.TrimSpace(keyword) {
+					canonical = dialectKeyword
+				}
+			}
+			if canonical == "" {
+				return Keywords{}, fmt.Errorf(`keyword "%s" doesn't exist for the kind "%s" in language "%s"`, keyword, kind, language)
+			}
+			keywords[language][kind] = canonical
+		}
+	}
+	return keywords, nil
+}
+
+// findLanguage restores the case of a language code with a region like zh-CN or sr-Latn,
+// the language is returned as is when no dialect matches
+func findLanguage(language string) string {
+	parts := strings.SplitN(strings.ToLower(language), "-", 2)
+	if gherkin.DialectsBuiltin().GetDialect(language) != nil || len(parts) != 2 || parts[1] == "" {
+		return language
+	}
+	for _, region := range []string{strings.ToUpper(parts[1]), strings.ToUpper(parts[1][:1]) + parts[1][1:]} {
+		if gherkin.DialectsBuiltin().GetDialect(parts[0]+"-"+region) != nil {
+			return parts[0] + "-" + region
+		}
+	}
+	return language
+}
+
+// keyword returns the canonical keyword of a kind in a language, the first
+// keyword listed in the dialect is returned when none is defined
+func (k Keywords) keyword(language string, kind string) string {
+	if keyword, ok := k[language][kind]; ok {
+		return keyword
+	}
+	return defaultKeyword(language, kind)
+}
+
+// canonicalizeTokens returns copies of tokens whose keyword is replaced
+// with the canonical keyword defined for their language and their kind
+func canonicalizeTokens(tokens []*gherkin.Token, keywords Keywords) []*gherkin.Token {
+	canonicalized := []*gherkin.Token{}
+	for _, token := range tokens {
+		t := *token
+		if keyword, ok := keywords[t.GherkinDialect][keywordKind(token)]; ok {
+			t.Keyword = keyword
+		}
+		canonicalized = append(canonicalized, &t)
+	}
+	return canonicalized
+}
@@ -0,0 +1,281 @@
+package ghokin
+
+import (
+	"os"
+	"testing"
+
+	gherkin "github.com/cucumber/gherkin/go/v28"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordKind(t *testing.T) {
+	type scenario struct {
+		token *gherkin.Token
+		kind  string
+	}
+
+	scenarios := []scenario{
+		{&gherkin.Token{Type: gherkin.TokenTypeFeatureLine, Keyword: "Business Need", GherkinDialect: "en"}, "feature"},
+		{&gherkin.Token{Type: gherkin.TokenTypeScenarioLine, Keyword: "Scenario Template", GherkinDialect: "en"}, "scenarioOutline"},
+		{&gherkin.Token{Type: gherkin.TokenTypeScenarioLine, Keyword: "Scénario", GherkinDialect: "fr"}, "scenario"},
+		{&gherkin.Token{Type: gherkin.TokenTypeStepLine, Keyword: "Étant donné que ", GherkinDialect: "fr"}, "given"},
+		{&gherkin.Token{Type: gherkin.TokenTypeStepLine, Keyword: "But ", GherkinDialect: "en"}, "but"},
+		{&gherkin.Token{Type: gherkin.TokenTypeStepLine, Keyword: "* ", GherkinDialect: "en"}, ""},
+		{&gherkin.Token{Type: gherkin.TokenTypeStepLine, Keyword: "Given ", GherkinDialect: "whatever"}, ""},
+		{&gherkin.Token{Type: gherkin.TokenTypeTableRow, GherkinDialect: "en"}, ""},
+	}
+
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.kind, keywordKind(scenario.token))
+	}
+}
+
+func TestFileManagerTranslate(t *testing.T) {
+	content := `@tag
+Feature: A feature
+  A description
+
+  Background:
+    Given a thing
+
+  Scenario Outline: An outline
+    Given a <thing>
+    And another thing
+    But not a third one
+    * a star step
+    When I do something
+    Then it works
+      """
+      Given a doc string
+      """
+
+    Examples:
+      | thing |
+      | Given |
+
+  Rule: A rule
+    Scenario: A scenario
+      Given a thing
+`
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/translate.feature", []byte(content), 0o777))
+
+	fileManager := NewFileManager(2, map[string]string{})
+	results, err := fileManager.Translate("en", []string{"/tmp/ghokin/translate.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results.Errors(), 0)
+
+	b, err := os.ReadFile("/tmp/ghokin/translate.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+
+	results, err = fileManager.Translate("fr", []string{"/tmp/ghokin/translate.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results.Errors(), 0)
+
+	translated := `# language: fr
+@tag
+Fonctionnalité: A feature
+  A description
+
+  Contexte:
+    Soit a thing
+
+  Plan du scénario: An outline
+    Soit a <thing>
+    Et que another thing
+    Mais que not a third one
+    * a star step
+    Quand I do something
+    Alors it works
+      """
+      Given a doc string
+      """
+
+    Exemples:
+      | thing |
+      | Given |
+
+  Règle: A rule
+    Scénario: A scenario
+      Soit a thing
+`
+	b, err = os.ReadFile("/tmp/ghokin/translate.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, translated, string(b))
+
+	results, err = fileManager.Translate("en", []string{"/tmp/ghokin/translate.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results.Errors(), 0)
+
+	b, err = os.ReadFile("/tmp/ghokin/translate.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "# language: en\n"+content, string(b))
+
+	_, err = fileManager.Translate("whatever", []string{"/tmp/ghokin/translate.feature"}, []string{"feature"})
+	assert.EqualError(t, err, `language "whatever" doesn't exist`)
+}
+
+func TestParseKeywords(t *testing.T) {
+	type scenario struct {
+		config   map[string]map[string]string
+		keywords Keywords
+		err      string
+	}
+
+	scenarios := []scenario{
+		{
+			map[string]map[string]string{
+				"en": {"scenario": "Scenario", "scenariooutline": "Scenario Template"},
+				"fr": {"given": "Étant donné", "And": "Et "},
+			},
+			Keywords{
+				"en": {"scenario": "Scenario", "scenarioOutline": "Scenario Template"},
+				"fr": {"given": "Étant donné ", "and": "Et "},
+			},
+			"",
+		},
+		{
+			map[string]map[string]string{"zh-cn": {"given": "假如"}, "sr-latn": {}},
+			Keywords{"zh-CN": {"given": "假如"}, "sr-Latn": {}},
+			"",
+		},
+		{
+			map[string]map[string]string{"whatever": {}},
+			Keywords{},
+			`language "whatever" doesn't exist`,
+		},
+		{
+			map[string]map[string]string{"en": {"whatever": "Given"}},
+			Keywords{},
+			`keyword kind "whatever" doesn't exist, it must be one of feature, rule, background, scenario, scenarioOutline, examples, given, when, then, and or but`,
+		},
+		{
+			map[string]map[string]string{"en": {"given": "When"}},
+			Keywords{},
+			`keyword "When" doesn't exist for the kind "given" in language "en"`,
+		},
+		{
+			map[string]map[string]string{"en": {"given": "*"}},
+			Keywords{},
+			`keyword "*" doesn't exist for the kind "given" in language "en"`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		keywords, err := ParseKeywords(scenario.config)
+		if scenario.err != "" {
+			assert.EqualError(t, err, scenario.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, scenario.keywords, keywords)
+	}
+}
+
+func TestFormatWithKeywords(t *testing.T) {
+	keywords, err := ParseKeywords(map[string]map[string]string{
+		"en": {"feature": "Feature", "scenario": "Scenario", "scenarioOutline": "Scenario Outline", "examples": "Examples"},
+		"fr": {"given": "Soit", "and": "Et", "scenario": "Scénario"},
+	})
+	assert.NoError(t, err)
+
+	type scenario struct {
+		name    string
+		options []Option
+		content string
+		output  string
+	}
+
+	scenarios := []scenario{
+		{
+			"Replace synonyms with canonical keywords",
+			[]Option{WithKeywords(keywords)},
+			`Business Need: test
+  Example: scenario
+    Given a thing
+
+  Scenario Template: outline
+    Given a <thing>
+
+    Scenarios:
+      | thing |
+      | book  |
+`,
+			`Feature: test
+  Scenario: scenario
+    Given a thing
+
+  Scenario Outline: outline
+    Given a <thing>
+
+    Examples:
+      | thing |
+      | book  |
+`,
+		},
+		{
+			"Replace synonyms with canonical keywords of the language of the file",
+			[]Option{WithKeywords(keywords)},
+			`# language: fr
+Fonctionnalité: test
+  Exemple: scénario
+    Étant donné une chose
+    Et que une autre chose
+    Quand une action
+`,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Soit une chose
+    Et une autre chose
+    Quand une action
+`,
+		},
+		{
+			"Use canonical keywords when rewriting step keywords",
+			[]Option{WithKeywords(keywords), WithStepKeywordStyle(StepKeywordConjunction)},
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Soit une chose
+    Sachant que une autre chose
+`,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Soit une chose
+    Et une autre chose
+`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			buf, err := format([]byte(scenario.content), 2, map[string]string{}, newOptions(append(scenario.options, WithIdempotencyCheck(true))))
+			assert.NoError(t, err)
+			assert.EqualValues(t, scenario.output, string(buf))
+		})
+	}
+}
+
+func TestFileManagerTranslateWithKeywords(t *testing.T) {
+	keywords, err := ParseKeywords(map[string]map[string]string{
+		"fr": {"scenario": "Scénario", "and": "Et"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/translate.feature", []byte("Feature: test\n  Scenario: scenario\n    Given a thing\n    And another thing\n"), 0o777))
+
+	results, err := NewFileManager(2, map[string]string{}, WithKeywords(keywords)).Translate("fr", []string{"/tmp/ghokin/translate.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results.Errors(), 0)
+
+	b, err := os.ReadFile("/tmp/ghokin/translate.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "# language: fr\nFonctionnalité: test\n  Scénario: scenario\n    Soit a thing\n    Et another thing\n", string(b))
+}
@@ -0,0 +1,147 @@
+package ghokin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Severity defines how serious a lint issue is
+type Severity int
+
+const (
+	// SeverityInfo is used for issues that are only informative
+	SeverityInfo Severity = iota
+	// SeverityWarning is used for issues that should be fixed
+	SeverityWarning
+	// SeverityError is used for issues that must be fixed
+	SeverityError
+)
+
+// String returns a human readable severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// ParseSeverity converts a severity name to a Severity
+func ParseSeverity(severity string) (Severity, error) {
+	for _, s := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if strings.EqualFold(s.String(), severity) {
+			return s, nil
+		}
+	}
+	return SeverityError, fmt.Errorf(`severity "%s" doesn't exist, it must be one of info, warning or error`, severity)
+}
+
+// RuleConfig holds the configuration of a rule, options are
+// free values specific to each rule
+type RuleConfig struct {
+	Enabled  bool
+	Severity Severity
+	Options  map[string]interface{}
+}
+
+// Int returns an integer option, def is returned if the option is not defined or invalid
+func (r RuleConfig) Int(key string, def int) int {
+	switch v := r.Options[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Bool returns a boolean option, def is returned if the option is not defined or invalid
+func (r RuleConfig) Bool(key string, def bool) bool {
+	if v, ok := r.Options[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// String returns a string option, def is returned if the option is not defined or invalid
+func (r RuleConfig) String(key string, def string) string {
+	if v, ok := r.Options[key].(string); ok {
+		return v
+	}
+	return def
+}
+
+// Strings returns a list of strings option, def is returned if the option is not defined or invalid
+func (r RuleConfig) Strings(key string, def []string) []string {
+	switch v := r.Options[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := []string{}
+		for _, value := range v {
+			values = append(values, fmt.Sprint(value))
+		}
+		return values
+	}
+	return def
+}
+
+// Rule inspects a parsed feature file and reports issues
+type Rule interface {
+	// ID identifies the rule in the config and in disable comments
+	ID() string
+	// Description explains what the rule reports
+	Description() string
+	// Defaults returns the config used when the rule is not configured
+	Defaults() RuleConfig
+	// Check inspects a single document
+	Check(doc *Document, config RuleConfig) []Issue
+}
+
+// SuiteRule is a rule that needs to inspect all documents at once
+type SuiteRule interface {
+	Rule
+	// CheckSuite inspects all documents found in the paths given to the linter
+	CheckSuite(docs []*Document, config RuleConfig) []Issue
+}
+
+// PreparedRule is a rule whose options are resolved once when the linter is created
+type PreparedRule interface {
+	Rule
+	// Prepare returns the rule to run with the resolved options of a config,
+	// an error is returned when an option is invalid
+	Prepare(config RuleConfig) (Rule, error)
+}
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]Rule{}
+)
+
+// RegisterRule adds a rule to the registry, a rule with the same ID is replaced
+func RegisterRule(rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[rule.ID()] = rule
+}
+
+// RegisteredRules returns all registered rules sorted by ID
+func RegisteredRules() []Rule {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	rs := []Rule{}
+	for _, rule := range rules {
+		rs = append(rs, rule)
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].ID() < rs[j].ID()
+	})
+	return rs
+}
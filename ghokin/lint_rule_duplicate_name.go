@@ -0,0 +1,123 @@
+package ghokin
+
+import (
+	"fmt"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+func init() {
+	RegisterRule(duplicateScenarioNameRule{})
+	RegisterRule(duplicateFeatureNameRule{})
+}
+
+type namedLocation struct {
+	doc      *Document
+	name     string
+	location *messages.Location
+}
+
+// reportDuplicateNames reports every element sharing its name with a previous one,
+// the first element is reported too with the location of all its duplicates
+func reportDuplicateNames(kind string, elements []namedLocation) []Issue {
+	issues := []Issue{}
+	groups := map[string][]namedLocation{}
+	names := []string{}
+	for _, element := range elements {
+		name := strings.TrimSpace(element.name)
+		if name == "" {
+			continue
+		}
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], element)
+	}
+	for _, name := range names {
+		group := groups[name]
+		if len(group) < 2 {
+			continue
+		}
+		original := group[0]
+		duplicates := []string{}
+		for _, duplicate := range group[1:] {
+			duplicates = append(duplicates, formatLocation(original.doc, duplicate.doc, duplicate.location))
+			issues = append(issues, newIssue(duplicate.doc, duplicate.location,
+				fmt.Sprintf(`%s name "%s" is already used at %s`, kind, name, formatLocation(duplicate.doc, original.doc, original.location))))
+		}
+		issues = append(issues, newIssue(original.doc, original.location,
+			fmt.Sprintf(`%s name "%s" is duplicated at %s`, kind, name, strings.Join(duplicates, ", "))))
+	}
+	return issues
+}
+
+// formatLocation returns a location relative to the document from, the file is omitted when both documents are the same
+func formatLocation(from *Document, to *Document, location *messages.Location) string {
+	if from == to {
+		return fmt.Sprintf("line %d", location.Line)
+	}
+	return fmt.Sprintf("%s:%d", to.File, location.Line)
+}
+
+type duplicateScenarioNameRule struct{}
+
+func (duplicateScenarioNameRule) ID() string {
+	return "duplicate-scenario-name"
+}
+
+func (duplicateScenarioNameRule) Description() string {
+	return `Report scenarios sharing the same name in a feature, with the option scope set to "rule" only scenarios from the same rule are compared`
+}
+
+func (duplicateScenarioNameRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityError, Options: map[string]interface{}{"scope": "feature"}}
+}
+
+func (duplicateScenarioNameRule) Check(doc *Document, config RuleConfig) []Issue {
+	groups := [][]namedLocation{{}}
+	for _, s := range doc.scopes() {
+		elements := []namedLocation{}
+		for _, scenario := range s.scenarios {
+			elements = append(elements, namedLocation{doc, scenario.Name, scenario.Location})
+		}
+		if config.String("scope", "feature") == "rule" {
+			groups = append(groups, elements)
+		} else {
+			groups[0] = append(groups[0], elements...)
+		}
+	}
+	issues := []Issue{}
+	for _, group := range groups {
+		issues = append(issues, reportDuplicateNames("scenario", group)...)
+	}
+	return issues
+}
+
+type duplicateFeatureNameRule struct{}
+
+func (duplicateFeatureNameRule) ID() string {
+	return "duplicate-feature-name"
+}
+
+func (duplicateFeatureNameRule) Description() string {
+	return "Report features sharing the same name across all linted files"
+}
+
+func (duplicateFeatureNameRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: false, Severity: SeverityError}
+}
+
+func (duplicateFeatureNameRule) Check(doc *Document, config RuleConfig) []Issue {
+	return []Issue{}
+}
+
+func (duplicateFeatureNameRule) CheckSuite(docs []*Document, config RuleConfig) []Issue {
+	elements := []namedLocation{}
+	for _, doc := range docs {
+		if feature := doc.Gherkin.Feature; feature != nil {
+			elements = append(elements, namedLocation{doc, feature.Name, feature.Location})
+		}
+	}
+	return reportDuplicateNames("feature", elements)
+}
@@ -0,0 +1,54 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintIssues(t *testing.T, rules []Rule, configs map[string]map[string]interface{}, paths ...string) []string {
+	linter, err := NewLinter(rules, configs)
+	assert.NoError(t, err)
+	results := linter.Lint(paths, []string{"feature"})
+	assert.Len(t, results.Errors, 0)
+	issues := []string{}
+	for _, issue := range results.Issues {
+		issues = append(issues, issue.String())
+	}
+	return issues
+}
+
+func TestDuplicateScenarioNameRule(t *testing.T) {
+	rules := []Rule{duplicateScenarioNameRule{}}
+
+	assert.Equal(t, []string{
+		`fixtures/lint/duplicate-name/feature1.feature:3:3: error: scenario name "A scenario" is duplicated at line 9 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:6:3: error: scenario name "Another scenario" is duplicated at line 18 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:9:3: error: scenario name "A scenario" is already used at line 3 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:18:5: error: scenario name "Another scenario" is already used at line 6 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:21:5: error: scenario name "A rule scenario" is duplicated at line 24 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:24:5: error: scenario name "A rule scenario" is already used at line 21 [duplicate-scenario-name]`,
+	}, lintIssues(t, rules, map[string]map[string]interface{}{}, "fixtures/lint/duplicate-name"))
+
+	assert.Equal(t, []string{
+		`fixtures/lint/duplicate-name/feature1.feature:3:3: warning: scenario name "A scenario" is duplicated at line 9 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:9:3: warning: scenario name "A scenario" is already used at line 3 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:21:5: warning: scenario name "A rule scenario" is duplicated at line 24 [duplicate-scenario-name]`,
+		`fixtures/lint/duplicate-name/feature1.feature:24:5: warning: scenario name "A rule scenario" is already used at line 21 [duplicate-scenario-name]`,
+	}, lintIssues(t, rules, map[string]map[string]interface{}{
+		"duplicate-scenario-name": {"scope": "rule", "severity": "warning"},
+	}, "fixtures/lint/duplicate-name"))
+}
+
+func TestDuplicateFeatureNameRule(t *testing.T) {
+	rules := []Rule{duplicateFeatureNameRule{}}
+
+	assert.Equal(t, []string{}, lintIssues(t, rules, map[string]map[string]interface{}{}, "fixtures/lint/duplicate-name"))
+
+	assert.Equal(t, []string{
+		`fixtures/lint/duplicate-name/feature1.feature:1:1: error: feature name "A feature" is duplicated at fixtures/lint/duplicate-name/feature2.feature:1 [duplicate-feature-name]`,
+		`fixtures/lint/duplicate-name/feature2.feature:1:1: error: feature name "A feature" is already used at fixtures/lint/duplicate-name/feature1.feature:1 [duplicate-feature-name]`,
+	}, lintIssues(t, rules, map[string]map[string]interface{}{
+		"duplicate-feature-name": {"enabled": true},
+	}, "fixtures/lint/duplicate-name"))
+}
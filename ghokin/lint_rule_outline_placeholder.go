@@ -0,0 +1,195 @@
+package ghokin
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+var placeholderRegexp = regexp.MustCompile(`<([^<>]+)>`)
+
+func init() {
+	RegisterRule(undefinedPlaceholderRule{})
+	RegisterRule(unusedExamplesColumnRule{})
+	RegisterRule(inconsistentExamplesHeaderRule{})
+}
+
+type placeholder struct {
+	name     string
+	location *messages.Location
+}
+
+// isOutline returns true for scenarios having examples or using a scenario outline keyword
+func isOutline(doc *Document, scenario *messages.Scenario) bool {
+	if len(scenario.Examples) > 0 {
+		return true
+	}
+	if doc.Dialect == nil {
+		return false
+	}
+	for _, keyword := range doc.Dialect.ScenarioOutlineKeywords() {
+		if keyword == scenario.Keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// extractPlaceholders finds placeholders used in the name, the steps, the data tables
+// and the doc strings of an outline
+func extractPlaceholders(doc *Document, scenario *messages.Scenario) []placeholder {
+	placeholders := []placeholder{}
+	find := func(line int64, text string) {
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(text, -1) {
+			column := 1
+			if index := strings.Index(doc.Line(line), match[0]); index >= 0 {
+				column = utf8.RuneCountInString(doc.Line(line)[:index]) + 1
+			}
+			placeholders = append(placeholders, placeholder{match[1], &messages.Location{Line: line, Column: int64(column)}})
+		}
+	}
+	find(scenario.Location.Line, scenario.Name)
+	for _, step := range scenario.Steps {
+		find(step.Location.Line, step.Text)
+		if step.DataTable != nil {
+			for _, row := range step.DataTable.Rows {
+				for _, cell := range row.Cells {
+					find(cell.Location.Line, cell.Value)
+				}
+			}
+		}
+		if step.DocString != nil {
+			for i, line := range strings.Split(step.DocString.Content, "\n") {
+				find(step.DocString.Location.Line+int64(i)+1, line)
+			}
+		}
+	}
+	return placeholders
+}
+
+func examplesColumns(examples *messages.Examples) []*messages.TableCell {
+	if examples.TableHeader == nil {
+		return []*messages.TableCell{}
+	}
+	return examples.TableHeader.Cells
+}
+
+type undefinedPlaceholderRule struct{}
+
+func (undefinedPlaceholderRule) ID() string {
+	return "undefined-placeholder"
+}
+
+func (undefinedPlaceholderRule) Description() string {
+	return "Report placeholders of scenario outlines having no matching column in any examples table"
+}
+
+func (undefinedPlaceholderRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityError}
+}
+
+func (undefinedPlaceholderRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		if !isOutline(doc, scenario) {
+			continue
+		}
+		columns := map[string]bool{}
+		for _, examples := range scenario.Examples {
+			for _, cell := range examplesColumns(examples) {
+				columns[cell.Value] = true
+			}
+		}
+		for _, p := range extractPlaceholders(doc, scenario) {
+			if !columns[p.name] {
+				issues = append(issues, newIssue(doc, p.location, fmt.Sprintf(`placeholder "<%s>" has no matching column in examples`, p.name)))
+			}
+		}
+	}
+	return issues
+}
+
+type unusedExamplesColumnRule struct{}
+
+func (unusedExamplesColumnRule) ID() string {
+	return "unused-examples-column"
+}
+
+func (unusedExamplesColumnRule) Description() string {
+	return "Report examples columns that are never used as a placeholder in their scenario outline"
+}
+
+func (unusedExamplesColumnRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (unusedExamplesColumnRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		used := map[string]bool{}
+		for _, p := range extractPlaceholders(doc, scenario) {
+			used[p.name] = true
+		}
+		for _, examples := range scenario.Examples {
+			for i, cell := range examplesColumns(examples) {
+				if !used[cell.Value] {
+					issue := newIssue(doc, cell.Location, fmt.Sprintf(`examples column "%s" is never used`, cell.Value))
+					issue.Fix = removeColumnFix(doc, append([]*messages.TableRow{examples.TableHeader}, examples.TableBody...), i)
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	return issues
+}
+
+type inconsistentExamplesHeaderRule struct{}
+
+func (inconsistentExamplesHeaderRule) ID() string {
+	return "inconsistent-examples-header"
+}
+
+func (inconsistentExamplesHeaderRule) Description() string {
+	return "Report examples tables whose columns differ from the first examples table of the same scenario outline"
+}
+
+func (inconsistentExamplesHeaderRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (inconsistentExamplesHeaderRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		if len(scenario.Examples) < 2 {
+			continue
+		}
+		reference := examplesColumnSet(scenario.Examples[0])
+		for _, examples := range scenario.Examples[1:] {
+			if columns := examplesColumnSet(examples); columns != reference {
+				issues = append(issues, newIssue(doc, examples.Location, fmt.Sprintf(
+					"examples columns (%s) differ from the columns of the examples at line %d (%s)",
+					columns, scenario.Examples[0].Location.Line, reference,
+				)))
+			}
+		}
+	}
+	return issues
+}
+
+// examplesColumnSet returns sorted and deduplicated columns of an examples table
+func examplesColumnSet(examples *messages.Examples) string {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, cell := range examplesColumns(examples) {
+		if !seen[cell.Value] {
+			seen[cell.Value] = true
+			columns = append(columns, cell.Value)
+		}
+	}
+	sort.Strings(columns)
+	return strings.Join(columns, ", ")
+}
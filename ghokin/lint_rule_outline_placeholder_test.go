@@ -0,0 +1,28 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndefinedPlaceholderRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/outline-placeholder/outline.feature:4:32: error: placeholder "<wallet>" has no matching column in examples [undefined-placeholder]`,
+		`fixtures/lint/outline-placeholder/outline.feature:10:15: error: placeholder "<items>" has no matching column in examples [undefined-placeholder]`,
+		`fixtures/lint/outline-placeholder/outline.feature:29:13: error: placeholder "<thing>" has no matching column in examples [undefined-placeholder]`,
+	}, lintIssues(t, []Rule{undefinedPlaceholderRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/outline-placeholder"))
+}
+
+func TestUnusedExamplesColumnRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/outline-placeholder/outline.feature:14:41: warning: examples column "unused" is never used [unused-examples-column]`,
+		`fixtures/lint/outline-placeholder/outline.feature:18:41: warning: examples column "unused" is never used [unused-examples-column]`,
+	}, lintIssues(t, []Rule{unusedExamplesColumnRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/outline-placeholder"))
+}
+
+func TestInconsistentExamplesHeaderRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/outline-placeholder/outline.feature:21:5: warning: examples columns (count, item, money) differ from the columns of the examples at line 13 (count, item, money, price, unused) [inconsistent-examples-header]`,
+	}, lintIssues(t, []Rule{inconsistentExamplesHeaderRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/outline-placeholder"))
+}
@@ -0,0 +1,179 @@
+package ghokin
+
+import (
+	"fmt"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+func init() {
+	RegisterRule(emptyScenarioRule{})
+	RegisterRule(emptyFeatureRule{})
+	RegisterRule(tooManyStepsRule{})
+	RegisterRule(stepOrderRule{})
+	RegisterRule(backgroundActionsRule{})
+}
+
+// resolvedStep is a step whose keyword type is resolved,
+// conjunctions take the type of the previous step
+type resolvedStep struct {
+	step        *messages.Step
+	keywordType messages.StepKeywordType
+}
+
+func resolveStepKeywordTypes(steps []*messages.Step) []resolvedStep {
+	resolved := []resolvedStep{}
+	previous := messages.StepKeywordType_UNKNOWN
+	for _, step := range steps {
+		keywordType := step.KeywordType
+		if keywordType == messages.StepKeywordType_CONJUNCTION || keywordType == messages.StepKeywordType_UNKNOWN {
+			keywordType = previous
+		}
+		resolved = append(resolved, resolvedStep{step, keywordType})
+		previous = keywordType
+	}
+	return resolved
+}
+
+type emptyScenarioRule struct{}
+
+func (emptyScenarioRule) ID() string {
+	return "empty-scenario"
+}
+
+func (emptyScenarioRule) Description() string {
+	return "Report scenarios without steps"
+}
+
+func (emptyScenarioRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (emptyScenarioRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		if len(scenario.Steps) == 0 {
+			issues = append(issues, newIssue(doc, scenario.Location, fmt.Sprintf(`scenario "%s" has no steps`, scenario.Name)))
+		}
+	}
+	return issues
+}
+
+type emptyFeatureRule struct{}
+
+func (emptyFeatureRule) ID() string {
+	return "empty-feature"
+}
+
+func (emptyFeatureRule) Description() string {
+	return "Report features without scenarios"
+}
+
+func (emptyFeatureRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (emptyFeatureRule) Check(doc *Document, config RuleConfig) []Issue {
+	if feature := doc.Gherkin.Feature; feature != nil && len(doc.scenarios()) == 0 {
+		return []Issue{newIssue(doc, feature.Location, fmt.Sprintf(`feature "%s" has no scenarios`, feature.Name))}
+	}
+	return []Issue{}
+}
+
+type tooManyStepsRule struct{}
+
+func (tooManyStepsRule) ID() string {
+	return "too-many-steps"
+}
+
+func (tooManyStepsRule) Description() string {
+	return `Report scenarios having more steps than the option "max", "max-by-language" overrides it per language`
+}
+
+func (tooManyStepsRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning, Options: map[string]interface{}{"max": 10}}
+}
+
+func (tooManyStepsRule) Check(doc *Document, config RuleConfig) []Issue {
+	max := config.Int("max", 10)
+	if languages, ok := config.Options["max-by-language"].(map[string]interface{}); ok && doc.Dialect != nil {
+		max = RuleConfig{Options: languages}.Int(doc.Dialect.Language, max)
+	}
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		if len(scenario.Steps) > max {
+			issues = append(issues, newIssue(doc, scenario.Location, fmt.Sprintf(`scenario "%s" has %d steps, more than the maximum of %d`, scenario.Name, len(scenario.Steps), max)))
+		}
+	}
+	return issues
+}
+
+type stepOrderRule struct{}
+
+func (stepOrderRule) ID() string {
+	return "step-order"
+}
+
+func (stepOrderRule) Description() string {
+	return "Report scenario steps not following the context, action and outcome order (Given, When, Then)"
+}
+
+func (stepOrderRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (stepOrderRule) Check(doc *Document, config RuleConfig) []Issue {
+	order := map[messages.StepKeywordType]int{
+		messages.StepKeywordType_CONTEXT: 1,
+		messages.StepKeywordType_ACTION:  2,
+		messages.StepKeywordType_OUTCOME: 3,
+	}
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		var previous resolvedStep
+		for _, step := range resolveStepKeywordTypes(scenario.Steps) {
+			if previous.step != nil && order[step.keywordType] < order[previous.keywordType] && order[step.keywordType] > 0 {
+				issues = append(issues, newIssue(doc, step.step.Location, fmt.Sprintf(
+					`"%s" step comes after a "%s" step at line %d`,
+					strings.TrimSpace(step.step.Keyword), strings.TrimSpace(previous.step.Keyword), previous.step.Location.Line,
+				)))
+			}
+			if order[step.keywordType] > order[previous.keywordType] {
+				previous = step
+			}
+		}
+	}
+	return issues
+}
+
+type backgroundActionsRule struct{}
+
+func (backgroundActionsRule) ID() string {
+	return "background-actions"
+}
+
+func (backgroundActionsRule) Description() string {
+	return "Report backgrounds containing action or outcome steps (When, Then)"
+}
+
+func (backgroundActionsRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (backgroundActionsRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, s := range doc.scopes() {
+		if s.background == nil {
+			continue
+		}
+		for _, step := range resolveStepKeywordTypes(s.background.Steps) {
+			if step.keywordType == messages.StepKeywordType_ACTION || step.keywordType == messages.StepKeywordType_OUTCOME {
+				issues = append(issues, newIssue(doc, step.step.Location, fmt.Sprintf(
+					`background contains a "%s" step, only context steps are expected`, strings.TrimSpace(step.step.Keyword),
+				)))
+			}
+		}
+	}
+	return issues
+}
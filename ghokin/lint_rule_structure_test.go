@@ -0,0 +1,49 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmptyScenarioRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/structure/structure.feature:9:3: warning: scenario "An empty scenario" has no steps [empty-scenario]`,
+	}, lintIssues(t, []Rule{emptyScenarioRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/structure"))
+}
+
+func TestEmptyFeatureRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/structure/empty.feature:1:1: warning: feature "Empty" has no scenarios [empty-feature]`,
+	}, lintIssues(t, []Rule{emptyFeatureRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/structure"))
+}
+
+func TestTooManyStepsRule(t *testing.T) {
+	assert.Equal(t, []string{}, lintIssues(t, []Rule{tooManyStepsRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/structure"))
+
+	assert.Equal(t, []string{
+		`fixtures/lint/structure/french.feature:4:3: warning: scenario "Un scénario dans le désordre" has 5 steps, more than the maximum of 4 [too-many-steps]`,
+		`fixtures/lint/structure/structure.feature:11:3: warning: scenario "A scenario in order" has 5 steps, more than the maximum of 3 [too-many-steps]`,
+		`fixtures/lint/structure/structure.feature:18:3: warning: scenario "A scenario out of order" has 6 steps, more than the maximum of 3 [too-many-steps]`,
+		`fixtures/lint/structure/structure.feature:26:3: warning: scenario "A long scenario" has 4 steps, more than the maximum of 3 [too-many-steps]`,
+	}, lintIssues(t, []Rule{tooManyStepsRule{}}, map[string]map[string]interface{}{
+		"too-many-steps": {"max": 3, "max-by-language": map[string]interface{}{"fr": 4}},
+	}, "fixtures/lint/structure"))
+}
+
+func TestStepOrderRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/structure/french.feature:6:5: warning: "Soit" step comes after a "Quand" step at line 5 [step-order]`,
+		`fixtures/lint/structure/french.feature:7:5: warning: "Et" step comes after a "Quand" step at line 5 [step-order]`,
+		`fixtures/lint/structure/structure.feature:20:5: warning: "Given" step comes after a "When" step at line 19 [step-order]`,
+		`fixtures/lint/structure/structure.feature:23:5: warning: "When" step comes after a "Then" step at line 21 [step-order]`,
+		`fixtures/lint/structure/structure.feature:24:5: warning: "*" step comes after a "Then" step at line 21 [step-order]`,
+	}, lintIssues(t, []Rule{stepOrderRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/structure"))
+}
+
+func TestBackgroundActionsRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/structure/structure.feature:6:5: warning: background contains a "When" step, only context steps are expected [background-actions]`,
+		`fixtures/lint/structure/structure.feature:7:5: warning: background contains a "But" step, only context steps are expected [background-actions]`,
+	}, lintIssues(t, []Rule{backgroundActionsRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/structure"))
+}
@@ -0,0 +1,268 @@
+package ghokin
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+func init() {
+	RegisterRule(tagNamingRule{})
+	RegisterRule(tagAllowListRule{})
+	RegisterRule(duplicateTagRule{})
+	RegisterRule(redundantTagRule{})
+}
+
+// taggedElement is a feature, a rule, a scenario or an examples table
+// alongside tags inherited from its parents
+type taggedElement struct {
+	kind      string
+	location  *messages.Location
+	tags      []*messages.Tag
+	inherited map[string]*messages.Tag
+}
+
+func extractTaggedElements(doc *Document) []taggedElement {
+	feature := doc.Gherkin.Feature
+	if feature == nil {
+		return []taggedElement{}
+	}
+	inherit := func(parent map[string]*messages.Tag, tags []*messages.Tag) map[string]*messages.Tag {
+		inherited := map[string]*messages.Tag{}
+		for name, tag := range parent {
+			inherited[name] = tag
+		}
+		for _, tag := range tags {
+			if _, ok := inherited[tag.Name]; !ok {
+				inherited[tag.Name] = tag
+			}
+		}
+		return inherited
+	}
+	elements := []taggedElement{{"feature", feature.Location, feature.Tags, map[string]*messages.Tag{}}}
+	featureTags := inherit(map[string]*messages.Tag{}, feature.Tags)
+	addScenarios := func(scenarios []*messages.Scenario, parent map[string]*messages.Tag) {
+		for _, scenario := range scenarios {
+			elements = append(elements, taggedElement{"scenario", scenario.Location, scenario.Tags, parent})
+			scenarioTags := inherit(parent, scenario.Tags)
+			for _, examples := range scenario.Examples {
+				elements = append(elements, taggedElement{"examples", examples.Location, examples.Tags, scenarioTags})
+			}
+		}
+	}
+	for _, s := range doc.scopes() {
+		parent := featureTags
+		if s.rule != nil {
+			elements = append(elements, taggedElement{"rule", s.rule.Location, s.rule.Tags, featureTags})
+			parent = inherit(featureTags, s.rule.Tags)
+		}
+		addScenarios(s.scenarios, parent)
+	}
+	return elements
+}
+
+func extractTags(doc *Document) []*messages.Tag {
+	tags := []*messages.Tag{}
+	for _, element := range extractTaggedElements(doc) {
+		tags = append(tags, element.tags...)
+	}
+	return tags
+}
+
+// tagNamingRule holds the pattern compiled when the linter is created
+type tagNamingRule struct {
+	re *regexp.Regexp
+}
+
+func (tagNamingRule) ID() string {
+	return "tag-naming"
+}
+
+func (tagNamingRule) Description() string {
+	return `Report tags not matching the regular expression defined with the option "pattern"`
+}
+
+func (tagNamingRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (tagNamingRule) Prepare(config RuleConfig) (Rule, error) {
+	pattern := config.String("pattern", "")
+	if pattern == "" {
+		return tagNamingRule{}, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf(`pattern "%s" is not a valid regular expression : %s`, pattern, err)
+	}
+	return tagNamingRule{re}, nil
+}
+
+func (r tagNamingRule) Check(doc *Document, config RuleConfig) []Issue {
+	if r.re == nil {
+		return []Issue{}
+	}
+	issues := []Issue{}
+	for _, tag := range extractTags(doc) {
+		if r.re.MatchString(tag.Name) {
+			continue
+		}
+		issue := newIssue(doc, tag.Location, fmt.Sprintf(`tag "%s" doesn't match the pattern "%s"`, tag.Name, r.re.String()))
+		if lower := strings.ToLower(tag.Name); r.re.MatchString(lower) {
+			issue.Fix = replaceTagFix(doc, tag, lower)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// tagAllowListRule holds the allow-list read when the linter is created
+type tagAllowListRule struct {
+	allowList map[string]bool
+}
+
+func (tagAllowListRule) ID() string {
+	return "tag-allow-list"
+}
+
+func (tagAllowListRule) Description() string {
+	return `Report tags missing from the allow-list defined with the option "tags" and/or with the option "file", a file containing one tag per line`
+}
+
+func (tagAllowListRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityError}
+}
+
+func (tagAllowListRule) Prepare(config RuleConfig) (Rule, error) {
+	allowed := config.Strings("tags", []string{})
+	if file := config.String("file", ""); file != "" {
+		content, err := os.ReadFile(file) // #nosec
+		if err != nil {
+			return nil, fmt.Errorf(`can't read the allow-list file : %s`, err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				allowed = append(allowed, line)
+			}
+		}
+	}
+	allowList := map[string]bool{}
+	for _, tag := range allowed {
+		allowList["@"+strings.TrimPrefix(tag, "@")] = true
+	}
+	return tagAllowListRule{allowList}, nil
+}
+
+func (r tagAllowListRule) Check(doc *Document, config RuleConfig) []Issue {
+	if len(r.allowList) == 0 {
+		return []Issue{}
+	}
+	issues := []Issue{}
+	for _, tag := range extractTags(doc) {
+		if r.allowList[tag.Name] {
+			continue
+		}
+		message := fmt.Sprintf(`tag "%s" is not allowed`, tag.Name)
+		if suggestion := closestTag(tag.Name, r.allowList); suggestion != "" {
+			message += fmt.Sprintf(`, did you mean "%s" ?`, suggestion)
+		}
+		issues = append(issues, newIssue(doc, tag.Location, message))
+	}
+	return issues
+}
+
+// closestTag finds the allowed tag with the smallest edit distance,
+// only tags distant of at most a third of their length are considered
+func closestTag(tag string, allowList map[string]bool) string {
+	closest := ""
+	best := len([]rune(tag))/3 + 1
+	for allowed := range allowList {
+		if d := levenshteinDistance(tag, allowed); d < best || d == best && allowed < closest {
+			closest, best = allowed, d
+		}
+	}
+	return closest
+}
+
+func levenshteinDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+type duplicateTagRule struct{}
+
+func (duplicateTagRule) ID() string {
+	return "duplicate-tag"
+}
+
+func (duplicateTagRule) Description() string {
+	return "Report tags defined several times on the same element"
+}
+
+func (duplicateTagRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (duplicateTagRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, element := range extractTaggedElements(doc) {
+		seen := map[string]*messages.Tag{}
+		for _, tag := range element.tags {
+			if original, ok := seen[tag.Name]; ok {
+				issue := newIssue(doc, tag.Location, fmt.Sprintf(`tag "%s" is already defined on this %s at line %d`, tag.Name, element.kind, original.Location.Line))
+				issue.Fix = replaceTagFix(doc, tag, "")
+				issues = append(issues, issue)
+				continue
+			}
+			seen[tag.Name] = tag
+		}
+	}
+	return issues
+}
+
+type redundantTagRule struct{}
+
+func (redundantTagRule) ID() string {
+	return "redundant-tag"
+}
+
+func (redundantTagRule) Description() string {
+	return "Report tags already inherited from a parent feature, rule or scenario outline"
+}
+
+func (redundantTagRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (redundantTagRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, element := range extractTaggedElements(doc) {
+		for _, tag := range element.tags {
+			if parent, ok := element.inherited[tag.Name]; ok {
+				issue := newIssue(doc, tag.Location, fmt.Sprintf(`tag "%s" is already inherited from line %d`, tag.Name, parent.Location.Line))
+				issue.Fix = replaceTagFix(doc, tag, "")
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,68 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagNamingRule(t *testing.T) {
+	assert.Equal(t, []string{}, lintIssues(t, []Rule{tagNamingRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/tag"))
+
+	assert.Equal(t, []string{
+		`fixtures/lint/tag/tag.feature:1:8: warning: tag "@Billing" doesn't match the pattern "^@[a-z]+(-[a-z]+)*$" [tag-naming]`,
+		`fixtures/lint/tag/tag.feature:12:11: warning: tag "@slow_Run" doesn't match the pattern "^@[a-z]+(-[a-z]+)*$" [tag-naming]`,
+	}, lintIssues(t, []Rule{tagNamingRule{}}, map[string]map[string]interface{}{
+		"tag-naming": {"pattern": "^@[a-z]+(-[a-z]+)*$"},
+	}, "fixtures/lint/tag"))
+
+	_, err := NewLinter([]Rule{tagNamingRule{}}, map[string]map[string]interface{}{
+		"tag-naming": {"pattern": "["},
+	})
+	assert.EqualError(t, err, `lint rule "tag-naming" : pattern "[" is not a valid regular expression : error parsing regexp: missing closing ]: `+"`[`")
+}
+
+func TestTagAllowListRule(t *testing.T) {
+	assert.Equal(t, []string{}, lintIssues(t, []Rule{tagAllowListRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/tag"))
+
+	assert.Equal(t, []string{
+		`fixtures/lint/tag/tag.feature:1:8: error: tag "@Billing" is not allowed, did you mean "@billing" ? [tag-allow-list]`,
+		`fixtures/lint/tag/tag.feature:4:3: error: tag "@smok" is not allowed, did you mean "@smoke" ? [tag-allow-list]`,
+		`fixtures/lint/tag/tag.feature:12:11: error: tag "@slow_Run" is not allowed [tag-allow-list]`,
+		`fixtures/lint/tag/tag.feature:17:3: error: tag "@fast" is not allowed [tag-allow-list]`,
+		`fixtures/lint/tag/tag.feature:20:5: error: tag "@fast" is not allowed [tag-allow-list]`,
+	}, lintIssues(t, []Rule{tagAllowListRule{}}, map[string]map[string]interface{}{
+		"tag-allow-list": {"tags": []interface{}{"billing"}, "file": "fixtures/lint/tag/allow-list.txt"},
+	}, "fixtures/lint/tag"))
+
+	_, err := NewLinter([]Rule{tagAllowListRule{}}, map[string]map[string]interface{}{
+		"tag-allow-list": {"file": "fixtures/lint/tag/missing.txt"},
+	})
+	assert.EqualError(t, err, `lint rule "tag-allow-list" : can't read the allow-list file : open fixtures/lint/tag/missing.txt: no such file or directory`)
+
+	_, err = NewLinter([]Rule{tagAllowListRule{}}, map[string]map[string]interface{}{
+		"tag-allow-list": {"enabled": false, "file": "fixtures/lint/tag/missing.txt"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestDuplicateTagRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/tag/tag.feature:4:14: warning: tag "@wip" is already defined on this scenario at line 4 [duplicate-tag]`,
+	}, lintIssues(t, []Rule{duplicateTagRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/tag"))
+}
+
+func TestRedundantTagRule(t *testing.T) {
+	assert.Equal(t, []string{
+		`fixtures/lint/tag/tag.feature:8:3: warning: tag "@smoke" is already inherited from line 1 [redundant-tag]`,
+		`fixtures/lint/tag/tag.feature:20:5: warning: tag "@fast" is already inherited from line 17 [redundant-tag]`,
+		`fixtures/lint/tag/tag.feature:20:11: warning: tag "@smoke" is already inherited from line 1 [redundant-tag]`,
+	}, lintIssues(t, []Rule{redundantTagRule{}}, map[string]map[string]interface{}{}, "fixtures/lint/tag"))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("@smoke", "@smoke"))
+	assert.Equal(t, 1, levenshteinDistance("@smok", "@smoke"))
+	assert.Equal(t, 3, levenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, 2, levenshteinDistance("été", "ete"))
+}
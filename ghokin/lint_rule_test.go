@@ -0,0 +1,60 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for name, expected := range map[string]Severity{
+		"info":    SeverityInfo,
+		"Warning": SeverityWarning,
+		"ERROR":   SeverityError,
+	} {
+		severity, err := ParseSeverity(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, severity)
+		assert.Equal(t, expected.String(), severity.String())
+	}
+
+	_, err := ParseSeverity("whatever")
+	assert.EqualError(t, err, `severity "whatever" doesn't exist, it must be one of info, warning or error`)
+}
+
+func TestRuleConfigOptions(t *testing.T) {
+	config := RuleConfig{Options: map[string]interface{}{
+		"int":     12,
+		"float":   float64(4),
+		"bool":    true,
+		"string":  "value",
+		"strings": []interface{}{"a", "b"},
+	}}
+
+	assert.Equal(t, 12, config.Int("int", 1))
+	assert.Equal(t, 4, config.Int("float", 1))
+	assert.Equal(t, 1, config.Int("string", 1))
+	assert.True(t, config.Bool("bool", false))
+	assert.False(t, config.Bool("whatever", false))
+	assert.Equal(t, "value", config.String("string", "default"))
+	assert.Equal(t, "default", config.String("int", "default"))
+	assert.Equal(t, []string{"a", "b"}, config.Strings("strings", []string{}))
+	assert.Equal(t, []string{"c"}, config.Strings("whatever", []string{"c"}))
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule(stepTextRule{})
+	defer func() {
+		rulesMu.Lock()
+		delete(rules, stepTextRule{}.ID())
+		rulesMu.Unlock()
+	}()
+
+	found := false
+	for _, rule := range RegisteredRules() {
+		if rule.ID() == "step-text" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,279 @@
+package ghokin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+const disableDirectivePrefix = "ghokin:disable"
+
+// Issue is a problem reported by a lint rule, lines and columns are numbered from 1,
+// Fix is nil when the issue can't be fixed safely
+type Issue struct {
+	RuleID   string
+	Severity Severity
+	File     string
+	Line     int
+	Column   int
+	Message  string
+	Fix      *Fix
+}
+
+// String dumps an issue as file:line:col: severity: message [rule-id]
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", i.File, i.Line, i.Column, i.Severity, i.Message, i.RuleID)
+}
+
+func newIssue(doc *Document, location *messages.Location, message string) Issue {
+	return Issue{
+		File:    doc.File,
+		Line:    int(location.Line),
+		Column:  int(location.Column),
+		Message: message,
+	}
+}
+
+// LintResults stores issues sorted by file, line and column alongside
+// errors that prevented files from being linted
+type LintResults struct {
+	Issues []Issue
+	Errors []error
+}
+
+// Failed returns true when an error occurred or when an issue has the error severity
+func (l LintResults) Failed() bool {
+	if len(l.Errors) > 0 {
+		return true
+	}
+	for _, issue := range l.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Linter runs lint rules on feature files
+type Linter struct {
+	rules   []Rule
+	configs map[string]RuleConfig
+	options options
+}
+
+// NewLinter creates a linter running the given rules, configs overrides per rule ID the default
+// config of a rule : "enabled" and "severity" keys are reserved, any other key is a rule option
+func NewLinter(rules []Rule, configs map[string]map[string]interface{}, opts ...Option) (Linter, error) {
+	linter := Linter{
+		rules:   append([]Rule{}, rules...),
+		configs: map[string]RuleConfig{},
+		options: newOptions(opts),
+	}
+	for _, rule := range rules {
+		linter.configs[rule.ID()] = rule.Defaults()
+	}
+	for id, values := range configs {
+		config, ok := linter.configs[id]
+		if !ok {
+			return Linter{}, fmt.Errorf(`lint rule "%s" doesn't exist`, id)
+		}
+		options := map[string]interface{}{}
+		for key, value := range config.Options {
+			options[key] = value
+		}
+		for key, value := range values {
+			switch key {
+			case "enabled":
+				enabled, ok := value.(bool)
+				if !ok {
+					return Linter{}, fmt.Errorf(`lint rule "%s" : enabled must be a boolean`, id)
+				}
+				config.Enabled = enabled
+			case "severity":
+				severity, err := ParseSeverity(fmt.Sprint(value))
+				if err != nil {
+					return Linter{}, fmt.Errorf(`lint rule "%s" : %s`, id, err)
+				}
+				config.Severity = severity
+			default:
+				options[key] = value
+			}
+		}
+		config.Options = options
+		linter.configs[id] = config
+	}
+	for i, rule := range linter.rules {
+		prepared, ok := rule.(PreparedRule)
+		if !ok || !linter.configs[rule.ID()].Enabled {
+			continue
+		}
+		r, err := prepared.Prepare(linter.configs[rule.ID()])
+		if err != nil {
+			return Linter{}, fmt.Errorf(`lint rule "%s" : %s`, rule.ID(), err)
+		}
+		linter.rules[i] = r
+	}
+	return linter, nil
+}
+
+// RuleStatus describes a rule alongside its effective config
+type RuleStatus struct {
+	Rule   Rule
+	Config RuleConfig
+}
+
+// String dumps a rule as id (state, severity) : description
+func (r RuleStatus) String() string {
+	state := "disabled"
+	if r.Config.Enabled {
+		state = "enabled"
+	}
+	return fmt.Sprintf("%s (%s, %s) : %s", r.Rule.ID(), state, r.Config.Severity, r.Rule.Description())
+}
+
+// Rules returns the rules run by the linter alongside their effective config
+func (l Linter) Rules() []RuleStatus {
+	statuses := []RuleStatus{}
+	for _, rule := range l.rules {
+		statuses = append(statuses, RuleStatus{rule, l.configs[rule.ID()]})
+	}
+	return statuses
+}
+
+// Lint runs all enabled rules on files and folders
+func (l Linter) Lint(paths []string, extensions []string) LintResults {
+	docs, errs := loadDocuments(paths, extensions, l.options.jobs)
+	results := LintResults{Issues: []Issue{}, Errors: errs}
+	for _, doc := range docs {
+		results.Issues = append(results.Issues, l.lintDocument(doc)...)
+	}
+	results.Issues = append(results.Issues, l.lintSuite(docs)...)
+	sortIssues(results.Issues)
+	return results
+}
+
+func (l Linter) lintDocument(doc *Document) []Issue {
+	issues := []Issue{}
+	for _, rule := range l.rules {
+		config := l.configs[rule.ID()]
+		if !config.Enabled {
+			continue
+		}
+		issues = append(issues, l.completeIssues(rule, config, doc.File, rule.Check(doc, config))...)
+	}
+	return filterDisabledIssues(doc, issues)
+}
+
+func (l Linter) lintSuite(docs []*Document) []Issue {
+	issues := []Issue{}
+	for _, rule := range l.rules {
+		config := l.configs[rule.ID()]
+		suiteRule, ok := rule.(SuiteRule)
+		if !config.Enabled || !ok {
+			continue
+		}
+		issues = append(issues, l.completeIssues(rule, config, "", suiteRule.CheckSuite(docs, config))...)
+	}
+	for _, doc := range docs {
+		issues = filterDisabledIssues(doc, issues)
+	}
+	return issues
+}
+
+func (l Linter) completeIssues(rule Rule, config RuleConfig, file string, issues []Issue) []Issue {
+	for i := range issues {
+		issues[i].RuleID = rule.ID()
+		issues[i].Severity = config.Severity
+		if issues[i].File == "" {
+			issues[i].File = file
+		}
+	}
+	return issues
+}
+
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch {
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		case a.Column != b.Column:
+			return a.Column < b.Column
+		default:
+			return a.RuleID < b.RuleID
+		}
+	})
+}
+
+// disableDirective is defined with a "# ghokin:disable=rule-id,another-rule-id" comment, without rule IDs
+// every rule is disabled. Before the feature line it applies to the whole file, otherwise
+// it applies to the following lines until the first line that is not blank, a comment or a tag line
+type disableDirective struct {
+	rules map[string]bool
+	from  int
+	to    int
+}
+
+func (d disableDirective) disables(issue Issue) bool {
+	return issue.Line >= d.from && issue.Line <= d.to && (len(d.rules) == 0 || d.rules[issue.RuleID])
+}
+
+func extractDisableDirectives(doc *Document) []disableDirective {
+	directives := []disableDirective{}
+	featureLine := len(doc.Lines) + 1
+	if doc.Gherkin.Feature != nil {
+		featureLine = int(doc.Gherkin.Feature.Location.Line)
+	}
+	for _, comment := range doc.Gherkin.Comments {
+		text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(comment.Text), "#"))
+		if !strings.HasPrefix(text, disableDirectivePrefix) {
+			continue
+		}
+		ids := strings.TrimPrefix(text, disableDirectivePrefix)
+		if ids != "" && !strings.HasPrefix(ids, "=") {
+			continue
+		}
+		directive := disableDirective{rules: map[string]bool{}, from: int(comment.Location.Line)}
+		for _, id := range strings.Split(strings.TrimPrefix(ids, "="), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				directive.rules[id] = true
+			}
+		}
+		if directive.from < featureLine {
+			directive.from, directive.to = 1, len(doc.Lines)
+		} else {
+			directive.to = directive.from
+			for directive.to < len(doc.Lines) {
+				line := strings.TrimSpace(doc.Line(int64(directive.to)))
+				if directive.to != directive.from && line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "@") {
+					break
+				}
+				directive.to++
+			}
+		}
+		directives = append(directives, directive)
+	}
+	return directives
+}
+
+func filterDisabledIssues(doc *Document, issues []Issue) []Issue {
+	directives := extractDisableDirectives(doc)
+	filtered := []Issue{}
+	for _, issue := range issues {
+		disabled := false
+		for _, directive := range directives {
+			if issue.File == doc.File && directive.disables(issue) {
+				disabled = true
+				break
+			}
+		}
+		if !disabled {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,181 @@
+package ghokin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scenarioNameRule struct{}
+
+func (scenarioNameRule) ID() string { return "scenario-name" }
+
+func (scenarioNameRule) Description() string { return "Report scenario names containing TODO" }
+
+func (scenarioNameRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityError, Options: map[string]interface{}{"word": "TODO"}}
+}
+
+func (scenarioNameRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		if strings.Contains(scenario.Name, config.String("word", "")) {
+			issues = append(issues, newIssue(doc, scenario.Location, "scenario name contains "+config.String("word", "")))
+		}
+	}
+	return issues
+}
+
+type stepTextRule struct{}
+
+func (stepTextRule) ID() string { return "step-text" }
+
+func (stepTextRule) Description() string { return "Report steps containing TODO" }
+
+func (stepTextRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: true, Severity: SeverityWarning}
+}
+
+func (stepTextRule) Check(doc *Document, config RuleConfig) []Issue {
+	issues := []Issue{}
+	for _, scenario := range doc.scenarios() {
+		for _, step := range scenario.Steps {
+			if strings.Contains(step.Text, "TODO") {
+				issues = append(issues, newIssue(doc, step.Location, "step contains TODO"))
+			}
+		}
+	}
+	return issues
+}
+
+type featureCountRule struct{}
+
+func (featureCountRule) ID() string { return "feature-count" }
+
+func (featureCountRule) Description() string { return "Report the number of features" }
+
+func (featureCountRule) Defaults() RuleConfig {
+	return RuleConfig{Enabled: false, Severity: SeverityInfo}
+}
+
+func (featureCountRule) Check(doc *Document, config RuleConfig) []Issue {
+	return nil
+}
+
+func (featureCountRule) CheckSuite(docs []*Document, config RuleConfig) []Issue {
+	return []Issue{{File: docs[0].File, Line: 1, Column: 1, Message: strings.Repeat("I", len(docs))}}
+}
+
+func TestLinterLint(t *testing.T) {
+	type scenario struct {
+		name    string
+		configs map[string]map[string]interface{}
+		paths   []string
+		test    func(LintResults)
+	}
+
+	scenarios := []scenario{
+		{
+			"Lint a folder with disable directives",
+			map[string]map[string]interface{}{},
+			[]string{"fixtures/lint"},
+			func(results LintResults) {
+				assert.Len(t, results.Errors, 0)
+				assert.True(t, results.Failed())
+				issues := []string{}
+				for _, issue := range results.Issues {
+					issues = append(issues, issue.String())
+				}
+				assert.Equal(t, []string{
+					"fixtures/lint/disable-file.feature:5:5: warning: step contains TODO [step-text]",
+					"fixtures/lint/disable.feature:6:5: warning: step contains TODO [step-text]",
+					"fixtures/lint/disable.feature:14:3: error: scenario name contains TODO [scenario-name]",
+				}, issues)
+			},
+		},
+		{
+			"Lint with a config overriding rules",
+			map[string]map[string]interface{}{
+				"scenario-name": {"severity": "warning", "word": "scenario"},
+				"step-text":     {"enabled": false},
+				"feature-count": {"enabled": true},
+			},
+			[]string{"fixtures/lint/disable.feature", "fixtures/lint/disable-file.feature"},
+			func(results LintResults) {
+				assert.Len(t, results.Errors, 0)
+				assert.False(t, results.Failed())
+				issues := []string{}
+				for _, issue := range results.Issues {
+					issues = append(issues, issue.String())
+				}
+				assert.Equal(t, []string{
+					"fixtures/lint/disable-file.feature:1:1: info: II [feature-count]",
+					"fixtures/lint/disable.feature:3:3: warning: scenario name contains scenario [scenario-name]",
+					"fixtures/lint/disable.feature:14:3: warning: scenario name contains scenario [scenario-name]",
+				}, issues)
+			},
+		},
+		{
+			"Lint files with errors",
+			map[string]map[string]interface{}{},
+			[]string{"fixtures/whatever.feature", "fixtures/multisize-table.input.feature", "fixtures/invalid.feature"},
+			func(results LintResults) {
+				assert.Len(t, results.Errors, 3)
+				assert.Contains(t, results.Errors[0].Error(), "fixtures/invalid.feature")
+				assert.Contains(t, results.Errors[1].Error(), "fixtures/multisize-table.input.feature")
+				assert.Contains(t, results.Errors[2].Error(), "fixtures/whatever.feature")
+				assert.Len(t, results.Issues, 0)
+				assert.True(t, results.Failed())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			linter, err := NewLinter([]Rule{featureCountRule{}, scenarioNameRule{}, stepTextRule{}}, scenario.configs)
+			assert.NoError(t, err)
+			scenario.test(linter.Lint(scenario.paths, []string{"feature"}))
+		})
+	}
+}
+
+func TestNewLinterWithInvalidConfig(t *testing.T) {
+	type scenario struct {
+		configs map[string]map[string]interface{}
+		errMsg  string
+	}
+
+	scenarios := []scenario{
+		{
+			map[string]map[string]interface{}{"whatever": {}},
+			`lint rule "whatever" doesn't exist`,
+		},
+		{
+			map[string]map[string]interface{}{"step-text": {"enabled": "yes"}},
+			`lint rule "step-text" : enabled must be a boolean`,
+		},
+		{
+			map[string]map[string]interface{}{"step-text": {"severity": "critical"}},
+			`lint rule "step-text" : severity "critical" doesn't exist, it must be one of info, warning or error`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		_, err := NewLinter([]Rule{stepTextRule{}}, scenario.configs)
+		assert.EqualError(t, err, scenario.errMsg)
+	}
+}
+
+func TestLinterRules(t *testing.T) {
+	linter, err := NewLinter([]Rule{featureCountRule{}, stepTextRule{}}, map[string]map[string]interface{}{})
+	assert.NoError(t, err)
+	statuses := []string{}
+	for _, status := range linter.Rules() {
+		statuses = append(statuses, status.String())
+	}
+	assert.Equal(t, []string{
+		"feature-count (disabled, info) : Report the number of features",
+		"step-text (enabled, warning) : Report steps containing TODO",
+	}, statuses)
+}
@@ -0,0 +1,113 @@
+package ghokin
+
+import "runtime"
+
+// Option customizes the behaviour of a FileManager or a StdinManager
+type Option func(*options)
+
+type options struct {
+	checkIdempotency bool
+	jobs             int
+	aliasLimiter     chan struct{}
+	cache            *cache
+	dryRun           bool
+	stepKeywordStyle StepKeywordStyle
+	tagStyle         TagStyle
+	blankLines       *BlankLines
+	maxLineLength    int
+	language         string
+	keywords         Keywords
+}
+
+func newOptions(opts []Option) options {
+	o := options{jobs: runtime.NumCPU()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithIdempotencyCheck formats every content a second time
+// and fails if the second pass changes the output of the first one
+func WithIdempotencyCheck(enabled bool) Option {
+	return func(o *options) {
+		o.checkIdempotency = enabled
+	}
+}
+
+// WithJobs defines how many files are processed concurrently when processing
+// a folder, it defaults to the number of CPUs
+func WithJobs(jobs int) Option {
+	return func(o *options) {
+		if jobs > 0 {
+			o.jobs = jobs
+		}
+	}
+}
+
+// WithAliasJobs limits how many alias commands can run at the same time,
+// 0 means there is no limit
+func WithAliasJobs(jobs int) Option {
+	return func(o *options) {
+		o.aliasLimiter = nil
+		if jobs > 0 {
+			o.aliasLimiter = make(chan struct{}, jobs)
+		}
+	}
+}
+
+// WithCache skips files already known to be well formatted, entries are stored in dir
+// and key must identify the configuration and the version used to format files
+func WithCache(dir string, key string) Option {
+	return func(o *options) {
+		o.cache = &cache{dir, key}
+	}
+}
+
+// WithDryRun doesn't write files, the diff between the original
+// and the processed content is stored in results instead
+func WithDryRun(enabled bool) Option {
+	return func(o *options) {
+		o.dryRun = enabled
+	}
+}
+
+// WithStepKeywordStyle defines how step keywords are rewritten when formatting,
+// step keywords are preserved by default
+func WithStepKeywordStyle(style StepKeywordStyle) Option {
+	return func(o *options) {
+		o.stepKeywordStyle = style
+	}
+}
+
+// WithTagStyle defines how tag lines are sorted, deduplicated and wrapped
+// when formatting, tag lines are preserved by default
+func WithTagStyle(style TagStyle) Option {
+	return func(o *options) {
+		o.tagStyle = style
+	}
+}
+
+// WithBlankLines defines how many empty lines are put between elements
+// when formatting, empty lines are preserved by default
+func WithBlankLines(policy BlankLines) Option {
+	return func(o *options) {
+		o.blankLines = &policy
+	}
+}
+
+// WithMaxLineLength reflows feature and scenario descriptions to fit in the given
+// line length including indentation, 0 preserves descriptions as they are
+func WithMaxLineLength(length int) Option {
+	return func(o *options) {
+		o.maxLineLength = length
+	}
+}
+
+// WithKeywords replaces keywords with the canonical keyword defined for their
+// language and their kind when formatting, keywords are preserved by default
+func WithKeywords(keywords Keywords) Option {
+	return func(o *options) {
+		o.keywords = keywords
+	}
+}
@@ -0,0 +1,51 @@
+package ghokin
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	type scenario struct {
+		name    string
+		options []Option
+		test    func(options)
+	}
+
+	scenarios := []scenario{
+		{
+			"Default options",
+			[]Option{},
+			func(o options) {
+				assert.False(t, o.checkIdempotency)
+				assert.Equal(t, runtime.NumCPU(), o.jobs)
+				assert.Nil(t, o.aliasLimiter)
+			},
+		},
+		{
+			"Override options",
+			[]Option{WithIdempotencyCheck(true), WithJobs(3), WithAliasJobs(2)},
+			func(o options) {
+				assert.True(t, o.checkIdempotency)
+				assert.Equal(t, 3, o.jobs)
+				assert.Equal(t, 2, cap(o.aliasLimiter))
+			},
+		},
+		{
+			"Ignore invalid jobs values",
+			[]Option{WithJobs(0), WithAliasJobs(-1)},
+			func(o options) {
+				assert.Equal(t, runtime.NumCPU(), o.jobs)
+				assert.Nil(t, o.aliasLimiter)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			scenario.test(newOptions(scenario.options))
+		})
+	}
+}
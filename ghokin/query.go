@@ -0,0 +1,76 @@
+package ghokin
+
+import (
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// QueryMatch is a scenario matching a tag expression
+type QueryMatch struct {
+	File string
+	Line int
+	Name string
+	// ExampleRows is the number of rows of matching examples of an outline
+	ExampleRows int
+	Outline     bool
+}
+
+// QueryResults gathers matching scenarios and errors of files that can't be parsed
+type QueryResults struct {
+	Matches []QueryMatch
+	Errors  []error
+}
+
+// Query lists scenarios of files or folders matching a tag expression, tags of
+// the feature and the rule are inherited by scenarios, an outline matches when
+// one of its examples matches with the tags of the examples, so never when it has none
+func (f FileManager) Query(expression TagExpression, paths []string, extensions []string) QueryResults {
+	docs, errs := loadDocuments(paths, extensions, f.options.jobs)
+	matches := []QueryMatch{}
+	for _, doc := range docs {
+		if doc.Gherkin.Feature == nil {
+			continue
+		}
+		featureTags := tagNames(doc.Gherkin.Feature.Tags)
+		for _, s := range doc.scopes() {
+			inherited := featureTags
+			if s.rule != nil {
+				inherited = append(append([]string{}, featureTags...), tagNames(s.rule.Tags)...)
+			}
+			for _, scenario := range s.scenarios {
+				if match, ok := queryScenario(doc, expression, inherited, scenario); ok {
+					match.File = doc.File
+					matches = append(matches, match)
+				}
+			}
+		}
+	}
+	return QueryResults{Matches: matches, Errors: errs}
+}
+
+func queryScenario(doc *Document, expression TagExpression, inherited []string, scenario *messages.Scenario) (QueryMatch, bool) {
+	match := QueryMatch{
+		Line:    int(scenario.Location.Line),
+		Name:    scenario.Name,
+		Outline: isOutline(doc, scenario),
+	}
+	tags := append(append([]string{}, inherited...), tagNames(scenario.Tags)...)
+	if !match.Outline {
+		return match, expression.Match(tags)
+	}
+	ok := false
+	for _, examples := range scenario.Examples {
+		if expression.Match(append(append([]string{}, tags...), tagNames(examples.Tags)...)) {
+			ok = true
+			match.ExampleRows += len(examples.TableBody)
+		}
+	}
+	return match, ok
+}
+
+func tagNames(tags []*messages.Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
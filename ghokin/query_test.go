@@ -0,0 +1,86 @@
+package ghokin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerQuery(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/a.feature", []byte("@smoke\n"+
+		"Feature: A\n"+
+		"  Scenario: A scenario\n"+
+		"    Given a thing\n"+
+		"\n"+
+		"  @wip\n"+
+		"  Scenario: A wip scenario\n"+
+		"    Given a thing\n"+
+		"\n"+
+		"  Scenario Outline: An outline\n"+
+		"    Given a <thing>\n"+
+		"\n"+
+		"    Examples:\n"+
+		"      | thing |\n"+
+		"      | book  |\n"+
+		"      | shelf |\n"+
+		"\n"+
+		"    @wip\n"+
+		"    Examples:\n"+
+		"      | thing |\n"+
+		"      | table |\n"+
+		"\n"+
+		"  Scenario Outline: An outline without examples\n"+
+		"    Given a <thing>\n"+
+		"\n"+
+		"  @wip\n"+
+		"  Rule: A rule\n"+
+		"    Scenario: A rule scenario\n"+
+		"      Given a thing\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/b.feature", []byte("Feature: B\n"+
+		"  @smoke\n"+
+		"  Scenario: Another scenario\n"+
+		"    Given a thing\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/c.feature", []byte("Whatever"), 0o777))
+
+	type scenario struct {
+		expression string
+		expected   []QueryMatch
+	}
+
+	scenarios := []scenario{
+		{
+			"@smoke and not @wip",
+			[]QueryMatch{
+				{"/tmp/ghokin/a.feature", 3, "A scenario", 0, false},
+				{"/tmp/ghokin/a.feature", 10, "An outline", 2, true},
+				{"/tmp/ghokin/b.feature", 3, "Another scenario", 0, false},
+			},
+		},
+		{
+			"@wip",
+			[]QueryMatch{
+				{"/tmp/ghokin/a.feature", 7, "A wip scenario", 0, false},
+				{"/tmp/ghokin/a.feature", 10, "An outline", 1, true},
+				{"/tmp/ghokin/a.feature", 28, "A rule scenario", 0, false},
+			},
+		},
+		{
+			"@unknown",
+			[]QueryMatch{},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.expression, func(t *testing.T) {
+			expression, err := ParseTagExpression(s.expression)
+			assert.NoError(t, err)
+			results := NewFileManager(2, map[string]string{}).Query(expression, []string{"/tmp/ghokin"}, []string{"feature"})
+			assert.Len(t, results.Errors, 1)
+			assert.Equal(t, s.expected, results.Matches)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package ghokin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
+)
+
+// refactoring computes edits changing a document,
+// a document without edit is left untouched
+type refactoring func(doc *Document) ([]LineEdit, error)
+
+// refactor applies a refactoring on files or folders, changed contents are formatted
+// and replace the content of files, the cache is never used to skip files
+func (f FileManager) refactor(paths []string, extensions []string, r refactoring) ProcessFileResults {
+	f.options.cache = nil
+	return f.process(paths, extensions, func(file string, content []byte) ([]byte, error) {
+		decoded, err := decode(content)
+		if err != nil {
+			return []byte{}, err
+		}
+		refactored, changed, err := refactorContent(file, decoded, r)
+		if err != nil || !changed {
+			return content, err
+		}
+		return f.transformContent(file, refactored)
+	}, replaceFileWithContent)
+}
+
+// refactorFile applies a refactoring on a single file, folders are rejected
+func (f FileManager) refactorFile(file string, r refactoring) ProcessFileResults {
+	info, err := os.Stat(file)
+	if err == nil && !info.Mode().IsRegular() {
+		err = fmt.Errorf("%s: a file is expected", file)
+	}
+	if err != nil {
+		return ProcessFileResults{{File: file, Status: StatusError, Err: err}}
+	}
+	return f.refactor([]string{file}, []string{}, r)
+}
+
+// refactorContent applies a refactoring on a content, false is returned when nothing changed
+func refactorContent(file string, content []byte, r refactoring) ([]byte, bool, error) {
+	contentTransformer := &transformer.ContentTransformer{}
+	contentTransformer.DetectSettings(content)
+	doc, err := parseDocument(file, contentTransformer.Prepare(content))
+	if err != nil {
+		return []byte{}, false, err
+	}
+	edits, err := r(doc)
+	if err != nil || len(edits) == 0 {
+		return content, false, err
+	}
+	lines := applyEdits(doc.Lines, edits)
+	return contentTransformer.Restore([]byte(strings.Join(lines, "\n") + "\n")), true, nil
+}
@@ -0,0 +1,180 @@
+package ghokin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// ExtractBackground moves the longest common prefix of Given steps of scenarios
+// of a file into the background of their feature or their rule, the background
+// is created when it doesn't exist. Steps are moved into the background of the
+// feature only when no rule defines its own background
+func (f FileManager) ExtractBackground(file string) ProcessFileResults {
+	return f.refactorFile(file, func(doc *Document) ([]LineEdit, error) {
+		scopes := doc.scopes()
+		if len(scopes) == 0 {
+			return []LineEdit{}, fmt.Errorf("%s: scenarios don't start with common Given steps", doc.File)
+		}
+		ruleBackground := false
+		for _, s := range scopes[1:] {
+			ruleBackground = ruleBackground || (s.background != nil && len(s.scenarios) > 0)
+		}
+		edits := []LineEdit{}
+		moved := map[*messages.Scenario]int{}
+		if !ruleBackground {
+			scenarios := doc.scenarios()
+			if count := commonGivenSteps(scenarios, moved); count > 0 {
+				edits = append(edits, moveStepsToBackground(doc, f.options.keywords, scopes[0], scenarios, moved, count)...)
+			}
+		}
+		for _, s := range scopes[1:] {
+			if count := commonGivenSteps(s.scenarios, moved); count > 0 {
+				edits = append(edits, moveStepsToBackground(doc, f.options.keywords, s, s.scenarios, moved, count)...)
+			}
+		}
+		if len(edits) == 0 {
+			return []LineEdit{}, fmt.Errorf("%s: scenarios don't start with common Given steps", doc.File)
+		}
+		return edits, nil
+	})
+}
+
+// commonGivenSteps returns the number of Given steps shared by scenarios
+// after steps already moved, at least two scenarios are needed
+func commonGivenSteps(scenarios []*messages.Scenario, moved map[*messages.Scenario]int) int {
+	if len(scenarios) < 2 {
+		return 0
+	}
+	count := 0
+	for {
+		first := scenarios[0]
+		index := moved[first] + count
+		if index >= len(first.Steps) || !isGivenStep(first.Steps, index) || hasPlaceholder(first, first.Steps[index]) {
+			return count
+		}
+		for _, scenario := range scenarios[1:] {
+			i := moved[scenario] + count
+			if i >= len(scenario.Steps) || !isGivenStep(scenario.Steps, i) || !sameStep(first.Steps[index], scenario.Steps[i]) {
+				return count
+			}
+		}
+		count++
+	}
+}
+
+// isGivenStep returns true when a step is a Given step or a conjunction following one
+func isGivenStep(steps []*messages.Step, index int) bool {
+	for i := index; i >= 0; i-- {
+		switch steps[i].KeywordType {
+		case messages.StepKeywordType_CONTEXT:
+			return true
+		case messages.StepKeywordType_CONJUNCTION:
+			continue
+		}
+		return false
+	}
+	return false
+}
+
+func sameStep(a *messages.Step, b *messages.Step) bool {
+	if a.Keyword != b.Keyword || a.Text != b.Text || (a.DocString == nil) != (b.DocString == nil) || (a.DataTable == nil) != (b.DataTable == nil) {
+		return false
+	}
+	if a.DocString != nil && (a.DocString.Content != b.DocString.Content || a.DocString.MediaType != b.DocString.MediaType) {
+		return false
+	}
+	return a.DataTable == nil || reflect.DeepEqual(tableValues(a.DataTable.Rows), tableValues(b.DataTable.Rows))
+}
+
+func tableValues(rows []*messages.TableRow) [][]string {
+	values := [][]string{}
+	for _, row := range rows {
+		cells := []string{}
+		for _, cell := range row.Cells {
+			cells = append(cells, cell.Value)
+		}
+		values = append(values, cells)
+	}
+	return values
+}
+
+// hasPlaceholder returns true when a step of an outline uses a placeholder
+func hasPlaceholder(scenario *messages.Scenario, step *messages.Step) bool {
+	content := step.Text
+	if step.DocString != nil {
+		content += step.DocString.Content
+	}
+	if step.DataTable != nil {
+		for _, row := range tableValues(step.DataTable.Rows) {
+			content += strings.Join(row, "")
+		}
+	}
+	for _, examples := range scenario.Examples {
+		if examples.TableHeader == nil {
+			continue
+		}
+		for _, cell := range examples.TableHeader.Cells {
+			if strings.Contains(content, "<"+cell.Value+">") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stepEnd returns the last line of a step and its argument
+func stepEnd(step *messages.Step) int64 {
+	switch {
+	case step.DataTable != nil && len(step.DataTable.Rows) > 0:
+		return step.DataTable.Rows[len(step.DataTable.Rows)-1].Location.Line
+	case step.DocString != nil && step.DocString.Content != "":
+		return step.DocString.Location.Line + int64(strings.Count(step.DocString.Content, "\n")) + 2
+	case step.DocString != nil:
+		return step.DocString.Location.Line + 1
+	}
+	return step.Location.Line
+}
+
+// moveStepsToBackground moves common steps of scenarios into the background of a scope
+func moveStepsToBackground(doc *Document, keywords Keywords, s scope, scenarios []*messages.Scenario, moved map[*messages.Scenario]int, count int) []LineEdit {
+	given := func(step *messages.Step) string {
+		line := []rune(doc.Line(step.Location.Line))
+		return string(line[:step.Location.Column-1]) + doc.keyword("given", keywords) + step.Text
+	}
+	edits := []LineEdit{}
+	steps := []string{}
+	for i, scenario := range scenarios {
+		first, last := scenario.Steps[moved[scenario]], scenario.Steps[moved[scenario]+count-1]
+		for number := first.Location.Line; number <= stepEnd(last); number++ {
+			if i == 0 {
+				steps = append(steps, doc.Line(number))
+			}
+			edits = append(edits, LineEdit{Line: int(number), Delete: true})
+		}
+		moved[scenario] += count
+		if next := moved[scenario]; next < len(scenario.Steps) && scenario.Steps[next].KeywordType == messages.StepKeywordType_CONJUNCTION {
+			edits = append(edits, LineEdit{Line: int(scenario.Steps[next].Location.Line), Text: given(scenario.Steps[next])})
+		}
+	}
+	if first := scenarios[0].Steps[moved[scenarios[0]]-count]; first.KeywordType == messages.StepKeywordType_CONJUNCTION && (s.background == nil || len(s.background.Steps) == 0) {
+		steps[0] = given(first)
+	}
+
+	if s.background != nil {
+		_, end := doc.block(s.background.Location.Line)
+		if len(s.background.Steps) > 0 {
+			end = stepEnd(s.background.Steps[len(s.background.Steps)-1])
+		}
+		return append(edits, LineEdit{Line: int(end), Text: strings.Join(append([]string{doc.Line(end)}, steps...), "\n")})
+	}
+	line := scenarios[0].Location.Line
+	if s.rule == nil {
+		line = doc.elementLines()[0]
+	}
+	start := doc.blockStart(line)
+	lines := append([]string{doc.keyword("background", keywords) + ":"}, steps...)
+	return append(edits, LineEdit{Line: int(start), Text: strings.Join(append(lines, "", doc.Line(start)), "\n")})
+}
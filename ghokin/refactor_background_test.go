@@ -0,0 +1,153 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerExtractBackground(t *testing.T) {
+	type scenario struct {
+		content  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"Feature: Background\n" +
+				"\n" +
+				"  @smoke\n" +
+				"  Scenario: First\n" +
+				"    Given a user\n" +
+				"      | name |\n" +
+				"      | bob  |\n" +
+				"    And a book\n" +
+				"    And a shelf\n" +
+				"    When he reads\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Scenario: Second\n" +
+				"      Given a user\n" +
+				"        | name |\n" +
+				"        | bob  |\n" +
+				"      And a book\n" +
+				"      And a chair\n" +
+				"      When he sits\n" +
+				"\n" +
+				"    Scenario: Third\n" +
+				"      Given a user\n" +
+				"        | name |\n" +
+				"        | bob  |\n" +
+				"      And a book\n" +
+				"      And a chair\n" +
+				"      Then he sits\n",
+			"Feature: Background\n" +
+				"\n" +
+				"  Background:\n" +
+				"    Given a user\n" +
+				"      | name |\n" +
+				"      | bob  |\n" +
+				"    And a book\n" +
+				"\n" +
+				"  @smoke\n" +
+				"  Scenario: First\n" +
+				"    Given a shelf\n" +
+				"    When he reads\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Background:\n" +
+				"      Given a chair\n" +
+				"\n" +
+				"    Scenario: Second\n" +
+				"      When he sits\n" +
+				"\n" +
+				"    Scenario: Third\n" +
+				"      Then he sits\n",
+		},
+		{
+			"Feature: Background\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Background:\n" +
+				"      Given a user\n" +
+				"\n" +
+				"    Scenario: First\n" +
+				"      Given a book\n" +
+				"      When he reads\n" +
+				"\n" +
+				"    Scenario Outline: Second\n" +
+				"      Given a book\n" +
+				"      And a <thing>\n" +
+				"\n" +
+				"      Examples:\n" +
+				"        | thing |\n" +
+				"        | shelf |\n" +
+				"\n" +
+				"  Rule: Another rule\n" +
+				"\n" +
+				"    Scenario: Third\n" +
+				"      Given a book\n",
+			"Feature: Background\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Background:\n" +
+				"      Given a user\n" +
+				"      Given a book\n" +
+				"\n" +
+				"    Scenario: First\n" +
+				"      When he reads\n" +
+				"\n" +
+				"    Scenario Outline: Second\n" +
+				"      Given a <thing>\n" +
+				"\n" +
+				"      Examples:\n" +
+				"        | thing |\n" +
+				"        | shelf |\n" +
+				"\n" +
+				"  Rule: Another rule\n" +
+				"\n" +
+				"    Scenario: Third\n" +
+				"      Given a book\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+		assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+		assert.NoError(t, os.WriteFile("/tmp/ghokin/background.feature", []byte(s.content), 0o777))
+
+		results := NewFileManager(2, map[string]string{}).ExtractBackground("/tmp/ghokin/background.feature")
+		assert.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		b, err := os.ReadFile("/tmp/ghokin/background.feature")
+		assert.NoError(t, err)
+		assert.Equal(t, s.expected, string(b))
+	}
+}
+
+func TestFileManagerExtractBackgroundWithoutCommonSteps(t *testing.T) {
+	content := "Feature: Background\n" +
+		"\n" +
+		"  Scenario: First\n" +
+		"    Given a user\n" +
+		"\n" +
+		"  Scenario: Second\n" +
+		"    When a user\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/background.feature", []byte(content), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).ExtractBackground("/tmp/ghokin/background.feature")
+	assert.Len(t, results, 1)
+	assert.Equal(t, errors.New("/tmp/ghokin/background.feature: scenarios don't start with common Given steps"), results[0].Err)
+
+	results = NewFileManager(2, map[string]string{}).ExtractBackground("/tmp/ghokin")
+	assert.Equal(t, []error{errors.New("/tmp/ghokin: a file is expected")}, results.Errors())
+}
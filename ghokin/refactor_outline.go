@@ -0,0 +1,560 @@
+package ghokin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// literalRegexp splits a step text in quoted strings, numbers, words and spaces
+var literalRegexp = regexp.MustCompile(`"[^"]*"|'[^']*'|-?\d+(?:[.,]\d+)?\b|[^\s"']+|\s+|.`)
+
+// valueRegexp matches parts of a step text which are values
+var valueRegexp = regexp.MustCompile(`^(?:"[^"]*"|'[^']*'|-?\d+(?:[.,]\d+)?)$`)
+
+// ScenarioSelector selects a scenario with one of its lines or with its name
+type ScenarioSelector struct {
+	Line int
+	Name string
+}
+
+func (s ScenarioSelector) find(doc *Document) (*messages.Scenario, scope, error) {
+	found := []*messages.Scenario{}
+	var foundScope scope
+	for _, sc := range doc.scopes() {
+		for _, scenario := range sc.scenarios {
+			start, end := doc.block(scenario.Location.Line)
+			if (s.Line > 0 && int64(s.Line) >= start && int64(s.Line) <= end) || (s.Line == 0 && scenario.Name == s.Name) {
+				found = append(found, scenario)
+				foundScope = sc
+			}
+		}
+	}
+	switch {
+	case len(found) == 1:
+		return found[0], foundScope, nil
+	case s.Line > 0:
+		return nil, scope{}, fmt.Errorf("%s: no scenario found at line %d", doc.File, s.Line)
+	case len(found) == 0:
+		return nil, scope{}, fmt.Errorf(`%s: no scenario named "%s"`, doc.File, s.Name)
+	}
+	return nil, scope{}, fmt.Errorf(`%s: several scenarios are named "%s", select one with its line`, doc.File, s.Name)
+}
+
+// literal is a part of a step text, values are quoted strings and numbers
+type literal struct {
+	text  string
+	value bool
+}
+
+func splitLiterals(text string) []literal {
+	literals := []literal{}
+	for _, part := range literalRegexp.FindAllString(text, -1) {
+		literals = append(literals, literal{part, valueRegexp.MatchString(part)})
+	}
+	return literals
+}
+
+// similarScenarios returns true when scenarios have the same steps
+// except for quoted strings, numbers and table cells
+func similarScenarios(a *messages.Scenario, b *messages.Scenario) bool {
+	if len(a.Examples) > 0 || len(b.Examples) > 0 || len(a.Steps) != len(b.Steps) {
+		return false
+	}
+	for i, step := range a.Steps {
+		other := b.Steps[i]
+		if step.Keyword != other.Keyword || (step.DocString == nil) != (other.DocString == nil) || (step.DataTable == nil) != (other.DataTable == nil) {
+			return false
+		}
+		if step.DocString != nil && (step.DocString.Content != other.DocString.Content || step.DocString.MediaType != other.DocString.MediaType) {
+			return false
+		}
+		literals, otherLiterals := splitLiterals(step.Text), splitLiterals(other.Text)
+		if len(literals) != len(otherLiterals) {
+			return false
+		}
+		for j, l := range literals {
+			if l.value != otherLiterals[j].value || (!l.value && l.text != otherLiterals[j].text) {
+				return false
+			}
+		}
+		if step.DataTable != nil {
+			if len(step.DataTable.Rows) != len(other.DataTable.Rows) {
+				return false
+			}
+			for j, row := range step.DataTable.Rows {
+				if len(row.Cells) != len(other.DataTable.Rows[j].Cells) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// placeholders allocates placeholder names which are unique
+type placeholders map[string]bool
+
+func (p placeholders) name(candidate string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, candidate)
+	if name == "" {
+		name = "value"
+	}
+	unique := name
+	for i := 2; p[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	p[unique] = true
+	return unique
+}
+
+// outlineColumn is a value differing between scenarios
+type outlineColumn struct {
+	name   string
+	values []string
+}
+
+// ConvertToOutline merges the selected scenario of a file and the scenarios of the same
+// feature or rule which only differ by quoted strings, numbers or table cells into a
+// scenario outline, differing values are moved to examples grouped by tags, the name of
+// the outline uses placeholders where names differ or a name column when it's not possible
+func (f FileManager) ConvertToOutline(file string, selector ScenarioSelector) ProcessFileResults {
+	return f.refactorFile(file, func(doc *Document) ([]LineEdit, error) {
+		selected, sc, err := selector.find(doc)
+		if err != nil {
+			return []LineEdit{}, err
+		}
+		group := []*messages.Scenario{}
+		for _, scenario := range sc.scenarios {
+			if scenario == selected || similarScenarios(selected, scenario) {
+				group = append(group, scenario)
+			}
+		}
+		if len(selected.Examples) > 0 || len(group) == 1 {
+			return []LineEdit{}, fmt.Errorf(`%s:%d: no scenario similar to "%s" can be merged into an outline`, doc.File, selected.Location.Line, selected.Name)
+		}
+
+		names := placeholders{}
+		columns := []outlineColumn{}
+		addColumn := func(candidate string, values []string) string {
+			name := names.name(candidate)
+			columns = append(columns, outlineColumn{name, values})
+			return "<" + name + ">"
+		}
+		steps := map[int64]string{}
+		rows := map[int64][]string{}
+		for i, step := range selected.Steps {
+			literals := splitLiterals(step.Text)
+			text := ""
+			for j, l := range literals {
+				values := []string{}
+				for _, scenario := range group {
+					values = append(values, splitLiterals(scenario.Steps[i].Text)[j].text)
+				}
+				if !l.value || allEqual(values) {
+					text += l.text
+					continue
+				}
+				if quote, ok := sameQuote(values); ok {
+					text += quote + addColumn(neighbourWord(literals, j, -1), unquote(values)) + quote
+					continue
+				}
+				text += addColumn(neighbourWord(literals, j, 1), values)
+			}
+			if text != step.Text {
+				steps[step.Location.Line] = text
+			}
+			if step.DataTable == nil {
+				continue
+			}
+			for j, row := range step.DataTable.Rows {
+				cells := []string{}
+				for k, cell := range row.Cells {
+					values := []string{}
+					for _, scenario := range group {
+						values = append(values, scenario.Steps[i].DataTable.Rows[j].Cells[k].Value)
+					}
+					if allEqual(values) {
+						cells = append(cells, cell.Value)
+						continue
+					}
+					candidate := ""
+					if j > 0 {
+						candidate = step.DataTable.Rows[0].Cells[k].Value
+					}
+					cells = append(cells, addColumn(candidate, values))
+				}
+				rows[row.Location.Line] = cells
+			}
+		}
+
+		name, ok := outlineName(group, columns)
+		if !ok {
+			column := outlineColumn{names.name("name"), []string{}}
+			for _, scenario := range group {
+				column.values = append(column.values, scenario.Name)
+			}
+			columns = append([]outlineColumn{column}, columns...)
+			name = "<" + column.name + ">"
+		}
+
+		common := []string{}
+		for _, tag := range selected.Tags {
+			if allHaveTag(group, tag.Name) {
+				common = append(common, tag.Name)
+			}
+		}
+		start, end := doc.block(selected.Location.Line)
+		lines := []string{}
+		for number := start; number < selected.Location.Line; number++ {
+			if strings.HasPrefix(strings.TrimSpace(doc.Line(number)), "#") {
+				lines = append(lines, doc.Line(number))
+			}
+		}
+		if len(common) > 0 {
+			lines = append(lines, strings.Join(common, " "))
+		}
+		lines = append(lines, doc.keyword("scenarioOutline", f.options.keywords)+": "+name)
+		lines = append(lines, rewriteScenarioLines(doc, selected.Location.Line+1, end, steps, rows, selected.Steps, func(line string) string { return line })...)
+
+		examplesKeyword := doc.keyword("examples", f.options.keywords)
+		tagGroups := []string{}
+		groupedRows := map[string][]int{}
+		for i, scenario := range group {
+			tags := []string{}
+			for _, tag := range scenario.Tags {
+				if !contains(common, tag.Name) && !contains(tags, tag.Name) {
+					tags = append(tags, tag.Name)
+				}
+			}
+			key := strings.Join(tags, " ")
+			if _, ok := groupedRows[key]; !ok {
+				tagGroups = append(tagGroups, key)
+			}
+			groupedRows[key] = append(groupedRows[key], i)
+		}
+		header := []string{}
+		for _, column := range columns {
+			header = append(header, column.name)
+		}
+		for _, tags := range tagGroups {
+			lines = append(lines, "")
+			if tags != "" {
+				lines = append(lines, tags)
+			}
+			lines = append(lines, examplesKeyword+":", tableRow(header))
+			for _, i := range groupedRows[tags] {
+				row := []string{}
+				for _, column := range columns {
+					row = append(row, column.values[i])
+				}
+				lines = append(lines, tableRow(row))
+			}
+		}
+
+		edits := replaceBlock(start, end, lines)
+		for _, scenario := range group {
+			if scenario != selected {
+				edits = append(edits, removeBlock(doc, scenario.Location.Line)...)
+			}
+		}
+		return edits, nil
+	})
+}
+
+// outlineName returns the name of an outline built from names of scenarios, parts
+// differing between names are replaced with placeholders of columns having the same
+// values, it returns false when names can't be rebuilt from columns
+func outlineName(group []*messages.Scenario, columns []outlineColumn) (string, bool) {
+	literals := [][]literal{}
+	for _, scenario := range group {
+		literals = append(literals, splitLiterals(scenario.Name))
+		if len(literals[len(literals)-1]) != len(literals[0]) {
+			return "", false
+		}
+	}
+	name := ""
+	for i, l := range literals[0] {
+		values := []string{}
+		for _, parts := range literals {
+			values = append(values, parts[i].text)
+		}
+		if allEqual(values) {
+			name += l.text
+			continue
+		}
+		quote, quoted := sameQuote(values)
+		placeholder := ""
+		for _, column := range columns {
+			if equalValues(column.values, values) {
+				placeholder = "<" + column.name + ">"
+			} else if quoted && equalValues(column.values, unquote(values)) {
+				placeholder = quote + "<" + column.name + ">" + quote
+			} else {
+				continue
+			}
+			break
+		}
+		if placeholder == "" {
+			return "", false
+		}
+		name += placeholder
+	}
+	return name, true
+}
+
+// ExpandOutline replaces the selected scenario outline of a file with a scenario per examples
+// row, placeholders are substituted and tags of examples are added to tags of the outline,
+// values of a row are appended to names when the name of the outline has no placeholder
+func (f FileManager) ExpandOutline(file string, selector ScenarioSelector) ProcessFileResults {
+	return f.refactorFile(file, func(doc *Document) ([]LineEdit, error) {
+		selected, _, err := selector.find(doc)
+		if err != nil {
+			return []LineEdit{}, err
+		}
+		count := 0
+		for _, examples := range selected.Examples {
+			count += len(examples.TableBody)
+		}
+		if count == 0 {
+			return []LineEdit{}, fmt.Errorf(`%s:%d: scenario "%s" has no examples row to expand`, doc.File, selected.Location.Line, selected.Name)
+		}
+
+		start, end := doc.block(selected.Location.Line)
+		stepsEnd := doc.blockStart(selected.Examples[0].Location.Line) - 1
+		for stepsEnd > selected.Location.Line && strings.TrimSpace(doc.Line(stepsEnd)) == "" {
+			stepsEnd--
+		}
+		lines := []string{}
+		for number := start; number < selected.Location.Line; number++ {
+			if strings.HasPrefix(strings.TrimSpace(doc.Line(number)), "#") {
+				lines = append(lines, doc.Line(number))
+			}
+		}
+		keyword, ok := doc.knownKeyword("scenario", f.options.keywords)
+		if !ok {
+			keyword = outlineScenarioKeyword(doc, selected)
+		}
+		expanded := 0
+		for _, examples := range selected.Examples {
+			if examples.TableHeader == nil {
+				continue
+			}
+			tags := []string{}
+			for _, tag := range append(append([]*messages.Tag{}, selected.Tags...), examples.Tags...) {
+				if !contains(tags, tag.Name) {
+					tags = append(tags, tag.Name)
+				}
+			}
+			for _, row := range examples.TableBody {
+				replacements := []string{}
+				values := []string{}
+				for i, cell := range examples.TableHeader.Cells {
+					if i < len(row.Cells) {
+						replacements = append(replacements, "<"+cell.Value+">", row.Cells[i].Value)
+						values = append(values, row.Cells[i].Value)
+					}
+				}
+				substitute := strings.NewReplacer(replacements...).Replace
+				steps := map[int64]string{}
+				rows := map[int64][]string{}
+				for _, step := range selected.Steps {
+					steps[step.Location.Line] = substitute(step.Text)
+					if step.DataTable == nil {
+						continue
+					}
+					for _, r := range step.DataTable.Rows {
+						cells := []string{}
+						for _, cell := range r.Cells {
+							cells = append(cells, substitute(cell.Value))
+						}
+						rows[r.Location.Line] = cells
+					}
+				}
+				name := substitute(selected.Name)
+				if name == selected.Name && count > 1 {
+					name += " (" + strings.Join(values, ", ") + ")"
+				}
+				if expanded > 0 {
+					lines = append(lines, "")
+				}
+				expanded++
+				if len(tags) > 0 {
+					lines = append(lines, strings.Join(tags, " "))
+				}
+				lines = append(lines, keyword+": "+name)
+				lines = append(lines, rewriteScenarioLines(doc, selected.Location.Line+1, stepsEnd, steps, rows, selected.Steps, substitute)...)
+			}
+		}
+		return replaceBlock(start, end, lines), nil
+	})
+}
+
+// rewriteScenarioLines returns lines of a scenario between two lines, texts of steps and
+// cells of tables are replaced and doc strings contents are rewritten with substitute
+func rewriteScenarioLines(doc *Document, from int64, to int64, steps map[int64]string, rows map[int64][]string, scenarioSteps []*messages.Step, substitute func(string) string) []string {
+	docStringLines := map[int64]bool{}
+	for _, step := range scenarioSteps {
+		if step.DocString == nil || step.DocString.Content == "" {
+			continue
+		}
+		for i := range strings.Split(step.DocString.Content, "\n") {
+			docStringLines[step.DocString.Location.Line+int64(i)+1] = true
+		}
+	}
+	stepKeywords := map[int64]*messages.Step{}
+	for _, step := range scenarioSteps {
+		stepKeywords[step.Location.Line] = step
+	}
+	lines := []string{}
+	for number := from; number <= to; number++ {
+		line := doc.Line(number)
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if text, ok := steps[number]; ok {
+			lines = append(lines, indent+stepKeywords[number].Keyword+text)
+			continue
+		}
+		if cells, ok := rows[number]; ok {
+			lines = append(lines, indent+tableRow(cells))
+			continue
+		}
+		if docStringLines[number] {
+			line = substitute(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// replaceBlock replaces lines of a block with other lines
+func replaceBlock(start int64, end int64, lines []string) []LineEdit {
+	edits := []LineEdit{{Line: int(start), Text: strings.Join(lines, "\n")}}
+	for number := start + 1; number <= end; number++ {
+		edits = append(edits, LineEdit{Line: int(number), Delete: true})
+	}
+	return edits
+}
+
+// removeBlock removes the block of an element alongside the empty lines following
+// it, or preceding it when it's the last element of the document
+func removeBlock(doc *Document, line int64) []LineEdit {
+	start, end := doc.block(line)
+	for end < int64(len(doc.Lines)) && strings.TrimSpace(doc.Line(end+1)) == "" {
+		end++
+	}
+	if end == int64(len(doc.Lines)) {
+		for start > 1 && strings.TrimSpace(doc.Line(start-1)) == "" {
+			start--
+		}
+	}
+	edits := []LineEdit{}
+	for number := start; number <= end; number++ {
+		edits = append(edits, LineEdit{Line: int(number), Delete: true})
+	}
+	return edits
+}
+
+func tableRow(cells []string) string {
+	escaped := []string{}
+	for _, cell := range cells {
+		escaped = append(escaped, escapeCell(cell))
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// neighbourWord returns the closest word before or after a literal
+func neighbourWord(literals []literal, index int, direction int) string {
+	for i := index + direction; i >= 0 && i < len(literals); i += direction {
+		if strings.TrimSpace(literals[i].text) != "" {
+			if literals[i].value {
+				return ""
+			}
+			return literals[i].text
+		}
+	}
+	return ""
+}
+
+func sameQuote(values []string) (string, bool) {
+	quote := values[0][:1]
+	for _, value := range values {
+		if len(value) < 2 || value[:1] != quote || (quote != `"` && quote != "'") {
+			return "", false
+		}
+	}
+	return quote, true
+}
+
+func unquote(values []string) []string {
+	unquoted := []string{}
+	for _, value := range values {
+		unquoted = append(unquoted, value[1:len(value)-1])
+	}
+	return unquoted
+}
+
+func equalValues(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func allEqual(values []string) bool {
+	for _, value := range values {
+		if value != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func allHaveTag(scenarios []*messages.Scenario, name string) bool {
+	for _, scenario := range scenarios {
+		found := false
+		for _, tag := range scenario.Tags {
+			found = found || tag.Name == name
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// outlineScenarioKeyword returns the scenario keyword prefixing the keyword of an outline,
+// like Scenario for Scenario Outline, or the default scenario keyword
+func outlineScenarioKeyword(doc *Document, outline *messages.Scenario) string {
+	keyword := ""
+	for _, k := range doc.Dialect.Keywords["scenario"] {
+		if strings.HasPrefix(outline.Keyword, k) && len(k) > len(keyword) {
+			keyword = k
+		}
+	}
+	if keyword == "" {
+		return defaultKeyword(doc.language(), "scenario")
+	}
+	return keyword
+}
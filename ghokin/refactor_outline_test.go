@@ -0,0 +1,283 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerConvertToOutline(t *testing.T) {
+	content := "Feature: Outline\n" +
+		"\n" +
+		"  @smoke\n" +
+		"  Scenario: Buy a book\n" +
+		"    Given a user named \"bob\"\n" +
+		"    When he buys 3 books\n" +
+		"      | title | price |\n" +
+		"      | Dune  | 10    |\n" +
+		"    Then he pays 30\n" +
+		"\n" +
+		"  Scenario: Another thing\n" +
+		"    Given a user named \"bob\"\n" +
+		"\n" +
+		"  # A comment\n" +
+		"  @smoke @slow\n" +
+		"  Scenario: Buy books\n" +
+		"    Given a user named \"alice\"\n" +
+		"    When he buys 2 books\n" +
+		"      | title | price |\n" +
+		"      | Ubik  | 10    |\n" +
+		"    Then he pays 20\n"
+
+	converted := "Feature: Outline\n" +
+		"\n" +
+		"  @smoke\n" +
+		"  Scenario Outline: <name>\n" +
+		"    Given a user named \"<named>\"\n" +
+		"    When he buys <books> books\n" +
+		"      | title   | price |\n" +
+		"      | <title> | 10    |\n" +
+		"    Then he pays <value>\n" +
+		"\n" +
+		"    Examples:\n" +
+		"      | name       | named | books | title | value |\n" +
+		"      | Buy a book | bob   | 3     | Dune  | 30    |\n" +
+		"\n" +
+		"    @slow\n" +
+		"    Examples:\n" +
+		"      | name      | named | books | title | value |\n" +
+		"      | Buy books | alice | 2     | Ubik  | 20    |\n" +
+		"\n" +
+		"  Scenario: Another thing\n" +
+		"    Given a user named \"bob\"\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/outline.feature", []byte(content), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).ConvertToOutline("/tmp/ghokin/outline.feature", ScenarioSelector{Name: "Buy a book"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err := os.ReadFile("/tmp/ghokin/outline.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, converted, string(b))
+}
+
+func TestFileManagerConvertToOutlineName(t *testing.T) {
+	content := "Feature: Outline\n" +
+		"\n" +
+		"  Scenario: Buy 3 apples\n" +
+		"    Given I buy 3 fruits named \"apples\"\n" +
+		"\n" +
+		"  Scenario: Buy 5 pears\n" +
+		"    Given I buy 5 fruits named \"pears\"\n"
+
+	converted := "Feature: Outline\n" +
+		"\n" +
+		"  Scenario Outline: Buy <fruits> <named>\n" +
+		"    Given I buy <fruits> fruits named \"<named>\"\n" +
+		"\n" +
+		"    Examples:\n" +
+		"      | fruits | named  |\n" +
+		"      | 3      | apples |\n" +
+		"      | 5      | pears  |\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/outline.feature", []byte(content), 0o777))
+
+	fileManager := NewFileManager(2, map[string]string{})
+	results := fileManager.ConvertToOutline("/tmp/ghokin/outline.feature", ScenarioSelector{Line: 3})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err := os.ReadFile("/tmp/ghokin/outline.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, converted, string(b))
+
+	results = fileManager.ExpandOutline("/tmp/ghokin/outline.feature", ScenarioSelector{Line: 3})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err = os.ReadFile("/tmp/ghokin/outline.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+}
+
+func TestFileManagerExpandOutline(t *testing.T) {
+	content := "Feature: Outline\n" +
+		"\n" +
+		"  Rule: A rule\n" +
+		"\n" +
+		"    # A comment\n" +
+		"    @smoke\n" +
+		"    Scenario Outline: Buy <count> books\n" +
+		"      Given a user named \"<name>\"\n" +
+		"        | name   |\n" +
+		"        | <name> |\n" +
+		"      Then he reads\n" +
+		"        \"\"\"\n" +
+		"        <name> reads\n" +
+		"        \"\"\"\n" +
+		"\n" +
+		"      Examples:\n" +
+		"        | name | count |\n" +
+		"        | bob  | 3     |\n" +
+		"\n" +
+		"      @slow @smoke\n" +
+		"      Examples:\n" +
+		"        | name  | count |\n" +
+		"        | alice | 10    |\n" +
+		"\n" +
+		"    Scenario: Another thing\n" +
+		"      Given a thing\n"
+
+	expanded := "Feature: Outline\n" +
+		"\n" +
+		"  Rule: A rule\n" +
+		"\n" +
+		"    # A comment\n" +
+		"    @smoke\n" +
+		"    Scenario: Buy 3 books\n" +
+		"      Given a user named \"bob\"\n" +
+		"        | name |\n" +
+		"        | bob  |\n" +
+		"      Then he reads\n" +
+		"        \"\"\"\n" +
+		"        bob reads\n" +
+		"        \"\"\"\n" +
+		"\n" +
+		"    @smoke @slow\n" +
+		"    Scenario: Buy 10 books\n" +
+		"      Given a user named \"alice\"\n" +
+		"        | name  |\n" +
+		"        | alice |\n" +
+		"      Then he reads\n" +
+		"        \"\"\"\n" +
+		"        alice reads\n" +
+		"        \"\"\"\n" +
+		"\n" +
+		"    Scenario: Another thing\n" +
+		"      Given a thing\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/outline.feature", []byte(content), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).ExpandOutline("/tmp/ghokin/outline.feature", ScenarioSelector{Line: 9})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err := os.ReadFile("/tmp/ghokin/outline.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, expanded, string(b))
+}
+
+func TestFileManagerExpandOutlineKeyword(t *testing.T) {
+	type scenario struct {
+		content  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"Feature: Outline\n  Scenario Outline: A <thing>\n    Given a <thing>\n\n    Examples:\n      | thing |\n      | book  |\n",
+			"Feature: Outline\n  Scenario: A book\n    Given a book\n",
+		},
+		{
+			"Feature: Outline\n  Scenario Template: A <thing>\n    Given a <thing>\n\n    Examples:\n      | thing |\n      | book  |\n",
+			"Feature: Outline\n  Scenario: A book\n    Given a book\n",
+		},
+		{
+			"Feature: Outline\n  Scenario Outline: A <thing>\n    Given a <thing>\n\n    Examples:\n      | thing |\n      | book  |\n\n  Example: Another thing\n    Given a thing\n",
+			"Feature: Outline\n  Example: A book\n    Given a book\n\n  Example: Another thing\n    Given a thing\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+		assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+		assert.NoError(t, os.WriteFile("/tmp/ghokin/outline.feature", []byte(s.content), 0o777))
+
+		results := NewFileManager(2, map[string]string{}).ExpandOutline("/tmp/ghokin/outline.feature", ScenarioSelector{Line: 2})
+		assert.Empty(t, results.Errors())
+		b, err := os.ReadFile("/tmp/ghokin/outline.feature")
+		assert.NoError(t, err)
+		assert.Equal(t, s.expected, string(b))
+	}
+}
+
+func TestFileManagerOutlineErrors(t *testing.T) {
+	content := "Feature: Outline\n" +
+		"\n" +
+		"  Scenario: A scenario\n" +
+		"    Given a thing\n" +
+		"\n" +
+		"  Scenario: A scenario\n" +
+		"    When a thing\n" +
+		"\n" +
+		"  Scenario Outline: An outline\n" +
+		"    Given a <thing>\n" +
+		"\n" +
+		"    Examples:\n" +
+		"      | thing |\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/outline.feature", []byte(content), 0o777))
+
+	type scenario struct {
+		expand   bool
+		selector ScenarioSelector
+		err      error
+	}
+
+	scenarios := []scenario{
+		{
+			false,
+			ScenarioSelector{Line: 1},
+			errors.New("/tmp/ghokin/outline.feature: no scenario found at line 1"),
+		},
+		{
+			false,
+			ScenarioSelector{Name: "whatever"},
+			errors.New(`/tmp/ghokin/outline.feature: no scenario named "whatever"`),
+		},
+		{
+			false,
+			ScenarioSelector{Name: "A scenario"},
+			errors.New(`/tmp/ghokin/outline.feature: several scenarios are named "A scenario", select one with its line`),
+		},
+		{
+			false,
+			ScenarioSelector{Line: 4},
+			errors.New(`/tmp/ghokin/outline.feature:3: no scenario similar to "A scenario" can be merged into an outline`),
+		},
+		{
+			true,
+			ScenarioSelector{Name: "An outline"},
+			errors.New(`/tmp/ghokin/outline.feature:9: scenario "An outline" has no examples row to expand`),
+		},
+	}
+
+	for _, s := range scenarios {
+		fileManager := NewFileManager(2, map[string]string{})
+		results := fileManager.ConvertToOutline("/tmp/ghokin/outline.feature", s.selector)
+		if s.expand {
+			results = fileManager.ExpandOutline("/tmp/ghokin/outline.feature", s.selector)
+		}
+		assert.Len(t, results, 1)
+		assert.Equal(t, s.err, results[0].Err)
+	}
+
+	b, err := os.ReadFile("/tmp/ghokin/outline.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+
+	fileManager := NewFileManager(2, map[string]string{})
+	for _, results := range []ProcessFileResults{
+		fileManager.ConvertToOutline("/tmp/ghokin", ScenarioSelector{Line: 3}),
+		fileManager.ExpandOutline("/tmp/ghokin", ScenarioSelector{Line: 9}),
+	} {
+		assert.Equal(t, []error{errors.New("/tmp/ghokin: a file is expected")}, results.Errors())
+	}
+}
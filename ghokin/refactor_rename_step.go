@@ -0,0 +1,211 @@
+package ghokin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// parameterTypes maps cucumber expression parameter types to regular expressions,
+// quotes are kept in strings captured with {string}
+var parameterTypes = map[string]string{
+	"":           `(.*)`,
+	"int":        `(-?\d+)`,
+	"biginteger": `(-?\d+)`,
+	"byte":       `(-?\d+)`,
+	"short":      `(-?\d+)`,
+	"long":       `(-?\d+)`,
+	"float":      `(-?\d*[.,]?\d+)`,
+	"double":     `(-?\d*[.,]?\d+)`,
+	"bigdecimal": `(-?\d*[.,]?\d+)`,
+	"word":       `([^\s]+)`,
+	"string":     `("[^"]*"|'[^']*')`,
+}
+
+// ParseStepPattern builds a regular expression matching step texts from a regular expression
+// or from a cucumber expression. Like cucumber does, a pattern starting with ^, ending with $
+// or surrounded with slashes is a regular expression, any other pattern is a cucumber expression
+func ParseStepPattern(pattern string) (*regexp.Regexp, error) {
+	switch {
+	case len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/"):
+		return regexp.Compile(pattern[1 : len(pattern)-1])
+	case strings.HasPrefix(pattern, "^") || strings.HasSuffix(pattern, "$"):
+		return regexp.Compile(pattern)
+	}
+	expression, err := cucumberExpressionToRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(expression)
+}
+
+func cucumberExpressionToRegexp(expression string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, word := range splitCucumberExpression(expression) {
+		if strings.TrimSpace(word) == "" {
+			sb.WriteString(regexp.QuoteMeta(word))
+			continue
+		}
+		alternatives := splitUnescaped(word, '/')
+		if len(alternatives) > 1 {
+			quoted := []string{}
+			for _, alternative := range alternatives {
+				quoted = append(quoted, regexp.QuoteMeta(unescape(alternative)))
+			}
+			sb.WriteString("(?:" + strings.Join(quoted, "|") + ")")
+			continue
+		}
+		runes := []rune(word)
+		for i := 0; i < len(runes); i++ {
+			switch runes[i] {
+			case '\\':
+				if i+1 < len(runes) {
+					i++
+				}
+				sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+			case '{':
+				end := indexRune(runes, '}', i)
+				if end == -1 {
+					return "", fmt.Errorf(`parameter type in "%s" is not closed`, expression)
+				}
+				name := string(runes[i+1 : end])
+				parameter, ok := parameterTypes[name]
+				if !ok {
+					return "", fmt.Errorf(`parameter type "{%s}" doesn't exist`, name)
+				}
+				sb.WriteString(parameter)
+				i = end
+			case '(':
+				end := indexRune(runes, ')', i)
+				if end == -1 {
+					return "", fmt.Errorf(`optional text in "%s" is not closed`, expression)
+				}
+				sb.WriteString("(?:" + regexp.QuoteMeta(unescape(string(runes[i+1:end]))) + ")?")
+				i = end
+			default:
+				sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		}
+	}
+	sb.WriteString("$")
+	return sb.String(), nil
+}
+
+// splitCucumberExpression splits an expression in words and spaces, spaces
+// in parameter types and optional texts don't split words
+func splitCucumberExpression(expression string) []string {
+	parts := []string{}
+	current := []rune{}
+	depth := 0
+	escaped := false
+	for _, r := range expression {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '{' || r == '(':
+			depth++
+		case (r == '}' || r == ')') && depth > 0:
+			depth--
+		}
+		if depth == 0 && len(current) > 0 && unicode.IsSpace(r) != unicode.IsSpace(current[len(current)-1]) {
+			parts = append(parts, string(current))
+			current = []rune{}
+		}
+		current = append(current, r)
+	}
+	return append(parts, string(current))
+}
+
+// splitUnescaped splits a word on a separator not escaped with a backslash,
+// words containing parameter types or optional texts are never split
+func splitUnescaped(word string, separator rune) []string {
+	parts := []string{}
+	current := []rune{}
+	escaped := false
+	for _, r := range word {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '{' || r == '(':
+			return []string{word}
+		case r == separator:
+			parts = append(parts, string(current))
+			current = []rune{}
+			continue
+		}
+		current = append(current, r)
+	}
+	return append(parts, string(current))
+}
+
+func unescape(s string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func indexRune(runes []rune, r rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// RenameStep rewrites texts of steps matching pattern in files or folders, template can
+// refer to captured groups with $1 or ${1}, changed files are formatted
+func (f FileManager) RenameStep(pattern *regexp.Regexp, template string, paths []string, extensions []string) ProcessFileResults {
+	return f.refactor(paths, extensions, func(doc *Document) ([]LineEdit, error) {
+		edits := []LineEdit{}
+		for _, step := range extractSteps(doc) {
+			if !pattern.MatchString(step.Text) {
+				continue
+			}
+			text := pattern.ReplaceAllString(step.Text, template)
+			if text == step.Text {
+				continue
+			}
+			line := []rune(doc.Line(step.Location.Line))
+			start := int(step.Location.Column) - 1
+			if start < 0 || start > len(line) {
+				continue
+			}
+			edits = append(edits, LineEdit{
+				Line: int(step.Location.Line),
+				Text: string(line[:start]) + step.Keyword + text,
+			})
+		}
+		return edits, nil
+	})
+}
+
+// extractSteps returns steps of backgrounds and scenarios of a document
+func extractSteps(doc *Document) []*messages.Step {
+	steps := []*messages.Step{}
+	for _, s := range doc.scopes() {
+		if s.background != nil {
+			steps = append(steps, s.background.Steps...)
+		}
+		for _, scenario := range s.scenarios {
+			steps = append(steps, scenario.Steps...)
+		}
+	}
+	return steps
+}
@@ -0,0 +1,128 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStepPattern(t *testing.T) {
+	type scenario struct {
+		pattern  string
+		expected string
+		err      error
+	}
+
+	scenarios := []scenario{
+		{
+			"^I have (\\d+) cukes$",
+			"^I have (\\d+) cukes$",
+			nil,
+		},
+		{
+			"/I have (\\d+) cukes/",
+			"I have (\\d+) cukes",
+			nil,
+		},
+		{
+			"I have {int} cuke(s) in my belly/stomach",
+			"^I have (-?\\d+) cuke(?:s)? in my (?:belly|stomach)$",
+			nil,
+		},
+		{
+			"a {string} named {word} costs {float}.{}",
+			"^a (\"[^\"]*\"|'[^']*') named ([^\\s]+) costs (-?\\d*[.,]?\\d+)\\.(.*)$",
+			nil,
+		},
+		{
+			"a \\{int\\} and a\\/b",
+			"^a \\{int\\} and a/b$",
+			nil,
+		},
+		{
+			"a {whatever}",
+			"",
+			errors.New(`parameter type "{whatever}" doesn't exist`),
+		},
+		{
+			"a {int",
+			"",
+			errors.New(`parameter type in "a {int" is not closed`),
+		},
+		{
+			"a cuke(s",
+			"",
+			errors.New(`optional text in "a cuke(s" is not closed`),
+		},
+	}
+
+	for _, s := range scenarios {
+		re, err := ParseStepPattern(s.pattern)
+		if s.err != nil {
+			assert.Equal(t, s.err, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, s.expected, re.String())
+	}
+}
+
+func TestFileManagerRenameStep(t *testing.T) {
+	content := "Feature: Rename\r\n" +
+		"  Background:\r\n" +
+		"    Given I have 3 cukes\r\n" +
+		"\r\n" +
+		"  Rule: A rule\r\n" +
+		"    Scenario: A scenario\r\n" +
+		"      *   I have 42 cukes\r\n" +
+		"        | a | b |\r\n" +
+		"      Then I eat 1 cuke\r\n"
+
+	renamed := "Feature: Rename\r\n" +
+		"  Background:\r\n" +
+		"    Given I own 3 cucumbers\r\n" +
+		"\r\n" +
+		"  Rule: A rule\r\n" +
+		"    Scenario: A scenario\r\n" +
+		"      * I own 42 cucumbers\r\n" +
+		"        | a | b |\r\n" +
+		"      Then I eat 1 cuke\r\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/rename.feature", []byte(content), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/untouched.feature", []byte("Feature: Untouched\n    Scenario: A scenario\n      Then I eat 1 cuke\n"), 0o777))
+
+	pattern, err := ParseStepPattern("I have {int} cuke(s)")
+	assert.NoError(t, err)
+
+	results := NewFileManager(2, map[string]string{}, WithDryRun(true)).RenameStep(pattern, "I own $1 cucumbers", []string{"/tmp/ghokin/rename.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, unifiedDiff("/tmp/ghokin/rename.feature", "/tmp/ghokin/rename.feature", []byte(content), []byte(renamed)), results[0].Diff)
+	b, err := os.ReadFile("/tmp/ghokin/rename.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(b))
+
+	results = NewFileManager(2, map[string]string{}).RenameStep(pattern, "I own $1 cucumbers", []string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Len(t, results, 2)
+	assert.Empty(t, results.Errors())
+	b, err = os.ReadFile("/tmp/ghokin/rename.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, renamed, string(b))
+	b, err = os.ReadFile("/tmp/ghokin/untouched.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "Feature: Untouched\n    Scenario: A scenario\n      Then I eat 1 cuke\n", string(b))
+}
+
+func TestFileManagerRenameStepWithInvalidFile(t *testing.T) {
+	pattern, err := ParseStepPattern("a step")
+	assert.NoError(t, err)
+
+	results := NewFileManager(2, map[string]string{}).RenameStep(pattern, "another step", []string{"fixtures/invalid.feature"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.Equal(t, StatusError, results[0].Status)
+	assert.Error(t, results[0].Err)
+}
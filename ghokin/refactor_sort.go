@@ -0,0 +1,158 @@
+package ghokin
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// ScenarioSort defines how scenarios of a feature or a rule are ordered
+type ScenarioSort int
+
+const (
+	// ScenarioSortPreserve keeps scenarios in their original order
+	ScenarioSortPreserve ScenarioSort = iota
+	// ScenarioSortName sorts scenarios by name
+	ScenarioSortName
+	// ScenarioSortTag sorts scenarios by their tags sorted alphabetically,
+	// scenarios without tags come last
+	ScenarioSortTag
+)
+
+// String returns a human readable scenario sort
+func (s ScenarioSort) String() string {
+	switch s {
+	case ScenarioSortName:
+		return "name"
+	case ScenarioSortTag:
+		return "tag"
+	default:
+		return "preserve"
+	}
+}
+
+// ParseScenarioSort converts a sort name to a ScenarioSort
+func ParseScenarioSort(sort string) (ScenarioSort, error) {
+	for _, s := range []ScenarioSort{ScenarioSortPreserve, ScenarioSortName, ScenarioSortTag} {
+		if strings.EqualFold(s.String(), sort) {
+			return s, nil
+		}
+	}
+	return ScenarioSortPreserve, fmt.Errorf(`scenario sort "%s" doesn't exist, it must be one of preserve, name or tag`, sort)
+}
+
+// less compares two scenarios
+func (s ScenarioSort) less(a *messages.Scenario, b *messages.Scenario) bool {
+	switch s {
+	case ScenarioSortName:
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	case ScenarioSortTag:
+		tagsA, tagsB := sortedTags(a.Tags), sortedTags(b.Tags)
+		if tagsA == "" || tagsB == "" {
+			return tagsA != "" && tagsB == ""
+		}
+		return tagsA < tagsB
+	}
+	return false
+}
+
+func sortedTags(tags []*messages.Tag) string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, strings.ToLower(tag.Name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// lessValue compares two cells, numerically when both are numbers
+func lessValue(a string, b string) bool {
+	numberA, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	numberB, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errA == nil && errB == nil {
+		return numberA < numberB
+	}
+	return a < b
+}
+
+// Sort reorders scenarios of every feature and rule of files or folders, comments and
+// tags preceding a scenario move with it, and reorders rows of examples having the
+// column examplesColumn, nothing is done on examples when the column is empty
+func (f FileManager) Sort(scenarioSort ScenarioSort, examplesColumn string, paths []string, extensions []string) ProcessFileResults {
+	return f.refactor(paths, extensions, func(doc *Document) ([]LineEdit, error) {
+		lines := append([]string{}, doc.Lines...)
+		if examplesColumn != "" {
+			for _, scenario := range doc.scenarios() {
+				for _, examples := range scenario.Examples {
+					sortExamplesRows(doc, lines, examples, examplesColumn)
+				}
+			}
+		}
+		if scenarioSort != ScenarioSortPreserve {
+			for _, s := range doc.scopes() {
+				sortScenarios(doc, lines, s.scenarios, scenarioSort)
+			}
+		}
+		edits := []LineEdit{}
+		for i, line := range lines {
+			if line != doc.Lines[i] {
+				edits = append(edits, LineEdit{Line: i + 1, Text: line})
+			}
+		}
+		return edits, nil
+	})
+}
+
+// sortExamplesRows reorders lines of the rows of examples by the value of a column
+func sortExamplesRows(doc *Document, lines []string, examples *messages.Examples, column string) {
+	if examples.TableHeader == nil {
+		return
+	}
+	index := -1
+	for i, cell := range examples.TableHeader.Cells {
+		if cell.Value == column {
+			index = i
+		}
+	}
+	if index == -1 {
+		return
+	}
+	rows := append([]*messages.TableRow{}, examples.TableBody...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return lessValue(rows[i].Cells[index].Value, rows[j].Cells[index].Value)
+	})
+	for i, row := range rows {
+		lines[examples.TableBody[i].Location.Line-1] = doc.Line(row.Location.Line)
+	}
+}
+
+// sortScenarios reorders lines of scenarios, empty lines between them are kept in place
+func sortScenarios(doc *Document, lines []string, scenarios []*messages.Scenario, scenarioSort ScenarioSort) {
+	if len(scenarios) < 2 {
+		return
+	}
+	type block struct {
+		scenario   *messages.Scenario
+		start, end int64
+	}
+	blocks := []block{}
+	for _, scenario := range scenarios {
+		start, end := doc.block(scenario.Location.Line)
+		blocks = append(blocks, block{scenario, start, end})
+	}
+	sorted := append([]block{}, blocks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scenarioSort.less(sorted[i].scenario, sorted[j].scenario)
+	})
+	original := append([]string{}, lines...)
+	number := blocks[0].start
+	for i, b := range sorted {
+		number += int64(copy(lines[number-1:], original[b.start-1:b.end]))
+		if i+1 < len(blocks) {
+			number += int64(copy(lines[number-1:], original[blocks[i].end:blocks[i+1].start-1]))
+		}
+	}
+}
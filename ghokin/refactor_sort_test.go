@@ -0,0 +1,183 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScenarioSort(t *testing.T) {
+	type scenario struct {
+		sort     string
+		expected ScenarioSort
+		err      error
+	}
+
+	scenarios := []scenario{
+		{"preserve", ScenarioSortPreserve, nil},
+		{"Name", ScenarioSortName, nil},
+		{"tag", ScenarioSortTag, nil},
+		{"whatever", ScenarioSortPreserve, errors.New(`scenario sort "whatever" doesn't exist, it must be one of preserve, name or tag`)},
+	}
+
+	for _, s := range scenarios {
+		sort, err := ParseScenarioSort(s.sort)
+		assert.Equal(t, s.err, err)
+		assert.Equal(t, s.expected, sort)
+		if err == nil {
+			assert.Equal(t, strings.ToLower(s.sort), sort.String())
+		}
+	}
+}
+
+func TestFileManagerSort(t *testing.T) {
+	content := "Feature: Sort\n" +
+		"\n" +
+		"  # About b\n" +
+		"  @wip\n" +
+		"  Scenario: b\n" +
+		"    Given a thing\n" +
+		"\n" +
+		"\n" +
+		"  Scenario Outline: A\n" +
+		"    Given a <thing>\n" +
+		"\n" +
+		"    Examples:\n" +
+		"      | thing | count |\n" +
+		"      | chair | 10    |\n" +
+		"      | book  | 9     |\n" +
+		"      | shelf | 10    |\n" +
+		"\n" +
+		"  @smoke\n" +
+		"  Scenario: c\n" +
+		"    Given a thing\n" +
+		"\n" +
+		"  Rule: A rule\n" +
+		"\n" +
+		"    Scenario: z\n" +
+		"      Given a thing\n" +
+		"\n" +
+		"    Scenario: y\n" +
+		"      Given a thing\n"
+
+	type scenario struct {
+		scenarioSort   ScenarioSort
+		examplesColumn string
+		expected       string
+	}
+
+	scenarios := []scenario{
+		{
+			ScenarioSortName,
+			"count",
+			"Feature: Sort\n" +
+				"\n" +
+				"  Scenario Outline: A\n" +
+				"    Given a <thing>\n" +
+				"\n" +
+				"    Examples:\n" +
+				"      | thing | count |\n" +
+				"      | book  | 9     |\n" +
+				"      | chair | 10    |\n" +
+				"      | shelf | 10    |\n" +
+				"\n" +
+				"\n" +
+				"  # About b\n" +
+				"  @wip\n" +
+				"  Scenario: b\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"  @smoke\n" +
+				"  Scenario: c\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Scenario: y\n" +
+				"      Given a thing\n" +
+				"\n" +
+				"    Scenario: z\n" +
+				"      Given a thing\n",
+		},
+		{
+			ScenarioSortTag,
+			"",
+			"Feature: Sort\n" +
+				"\n" +
+				"  @smoke\n" +
+				"  Scenario: c\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"\n" +
+				"  # About b\n" +
+				"  @wip\n" +
+				"  Scenario: b\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"  Scenario Outline: A\n" +
+				"    Given a <thing>\n" +
+				"\n" +
+				"    Examples:\n" +
+				"      | thing | count |\n" +
+				"      | chair | 10    |\n" +
+				"      | book  | 9     |\n" +
+				"      | shelf | 10    |\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Scenario: z\n" +
+				"      Given a thing\n" +
+				"\n" +
+				"    Scenario: y\n" +
+				"      Given a thing\n",
+		},
+		{
+			ScenarioSortPreserve,
+			"thing",
+			"Feature: Sort\n" +
+				"\n" +
+				"  # About b\n" +
+				"  @wip\n" +
+				"  Scenario: b\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"\n" +
+				"  Scenario Outline: A\n" +
+				"    Given a <thing>\n" +
+				"\n" +
+				"    Examples:\n" +
+				"      | thing | count |\n" +
+				"      | book  | 9     |\n" +
+				"      | chair | 10    |\n" +
+				"      | shelf | 10    |\n" +
+				"\n" +
+				"  @smoke\n" +
+				"  Scenario: c\n" +
+				"    Given a thing\n" +
+				"\n" +
+				"  Rule: A rule\n" +
+				"\n" +
+				"    Scenario: z\n" +
+				"      Given a thing\n" +
+				"\n" +
+				"    Scenario: y\n" +
+				"      Given a thing\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+		assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+		assert.NoError(t, os.WriteFile("/tmp/ghokin/sort.feature", []byte(content), 0o777))
+
+		results := NewFileManager(2, map[string]string{}).Sort(s.scenarioSort, s.examplesColumn, []string{"/tmp/ghokin"}, []string{"feature"})
+		assert.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		b, err := os.ReadFile("/tmp/ghokin/sort.feature")
+		assert.NoError(t, err)
+		assert.Equal(t, s.expected, string(b))
+	}
+}
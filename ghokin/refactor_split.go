@@ -0,0 +1,452 @@
+package ghokin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
+	gherkin "github.com/cucumber/gherkin/go/v28"
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// generatedFile is a file produced by a refactoring, a nil content removes the file
+type generatedFile struct {
+	path    string
+	content []byte
+}
+
+// sourceDocument is a document alongside the transformer
+// restoring its end of lines and its BOM
+type sourceDocument struct {
+	doc         *Document
+	transformer *transformer.ContentTransformer
+}
+
+func readSourceDocument(file string) (sourceDocument, error) {
+	original, err := os.ReadFile(file) // #nosec
+	if err != nil {
+		return sourceDocument{}, err
+	}
+	content, err := decode(original)
+	if err != nil {
+		return sourceDocument{}, err
+	}
+	contentTransformer := &transformer.ContentTransformer{}
+	contentTransformer.DetectSettings(content)
+	doc, err := parseDocument(file, content)
+	if err != nil {
+		return sourceDocument{}, err
+	}
+	return sourceDocument{doc, contentTransformer}, nil
+}
+
+// writeFiles formats and writes generated files, in dry run mode diffs are returned instead.
+// Nothing is written until every file is formatted, contents are written to temporary files
+// renamed once all are written and files are removed last, so a failure never loses a scenario
+func (f FileManager) writeFiles(source sourceDocument, files []generatedFile) ProcessFileResults {
+	results := ProcessFileResults{}
+	contents := [][]byte{}
+	for _, file := range files {
+		original, err := os.ReadFile(file.path) // #nosec
+		if err != nil && !os.IsNotExist(err) {
+			return ProcessFileResults{{File: file.path, Status: StatusError, Err: err}}
+		}
+		content := []byte{}
+		if file.content != nil {
+			content, err = f.transformContent(file.path, source.transformer.Restore(file.content))
+			if err != nil {
+				return ProcessFileResults{{File: file.path, Status: StatusError, Err: err}}
+			}
+		}
+		result := ProcessFileResult{File: file.path, Status: StatusOK, Changed: file.content == nil || string(original) != string(content)}
+		if f.options.dryRun {
+			result.Diff = unifiedDiff(file.path, file.path, original, content)
+		}
+		results = append(results, result)
+		contents = append(contents, content)
+	}
+	if !f.options.dryRun {
+		if file, err := replaceFiles(files, contents, results); err != nil {
+			return ProcessFileResults{{File: file, Status: StatusError, Err: ProcessFileError{Message: err.Error(), File: file}}}
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].File < results[j].File
+	})
+	return results
+}
+
+// replaceFiles writes changed contents to temporary files renamed once all
+// are written, files without content are then removed, the file failing is returned with the error
+func replaceFiles(files []generatedFile, contents [][]byte, results ProcessFileResults) (string, error) {
+	temporaries := map[int]string{}
+	removeTemporaries := func() {
+		for _, temporary := range temporaries {
+			_ = os.Remove(temporary)
+		}
+	}
+	for i, file := range files {
+		if file.content == nil || !results[i].Changed {
+			continue
+		}
+		temporary, err := writeTemporaryFile(file.path, contents[i])
+		if err != nil {
+			removeTemporaries()
+			return file.path, err
+		}
+		temporaries[i] = temporary
+	}
+	for i, file := range files {
+		if temporary, ok := temporaries[i]; ok {
+			if err := os.Rename(temporary, file.path); err != nil {
+				removeTemporaries()
+				return file.path, err
+			}
+			delete(temporaries, i)
+		}
+	}
+	for _, file := range files {
+		if file.content == nil {
+			if err := os.Remove(file.path); err != nil {
+				return file.path, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// writeTemporaryFile writes content to a temporary file of the folder of file,
+// the mode of file is kept when it exists
+func writeTemporaryFile(file string, content []byte) (string, error) {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(file); err == nil {
+		mode = info.Mode().Perm()
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return "", err
+	}
+	_, err = temporary.Write(content)
+	if err == nil {
+		err = temporary.Chmod(mode)
+	}
+	if closeErr := temporary.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(temporary.Name())
+		return "", err
+	}
+	return temporary.Name(), nil
+}
+
+// Split moves every rule of a file, or when tags are given the scenarios having one of
+// these tags, in a file of its own named after the rule or the tag. The language, the
+// header, the description and the background of the feature are copied in each file,
+// the original file keeps remaining scenarios and is removed when none remains
+func (f FileManager) Split(file string, tags []string) ProcessFileResults {
+	source, err := readSourceDocument(file)
+	if err != nil {
+		return ProcessFileResults{{File: file, Status: StatusError, Err: err}}
+	}
+	doc := source.doc
+	scopes := doc.scopes()
+	if len(scopes) == 0 {
+		return ProcessFileResults{{File: file, Status: StatusError, Err: fmt.Errorf("%s: no feature to split", file)}}
+	}
+
+	type group struct {
+		name      string
+		scenarios map[*messages.Scenario]bool
+		rules     map[*messages.Rule]bool
+	}
+	groups := []group{}
+	grouped := map[*messages.Scenario]bool{}
+	if len(tags) == 0 {
+		for _, s := range scopes[1:] {
+			g := group{s.rule.Name, map[*messages.Scenario]bool{}, map[*messages.Rule]bool{s.rule: true}}
+			for _, scenario := range s.scenarios {
+				g.scenarios[scenario] = true
+				grouped[scenario] = true
+			}
+			groups = append(groups, g)
+		}
+	}
+	for _, tag := range tags {
+		g := group{strings.TrimPrefix(tag, "@"), map[*messages.Scenario]bool{}, map[*messages.Rule]bool{}}
+		for _, s := range scopes {
+			for _, scenario := range s.scenarios {
+				if !grouped[scenario] && (hasTag(scenario.Tags, tag) || (s.rule != nil && hasTag(s.rule.Tags, tag))) {
+					g.scenarios[scenario] = true
+					grouped[scenario] = true
+				}
+			}
+		}
+		if len(g.scenarios) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		return ProcessFileResults{{File: file, Status: StatusError, Err: fmt.Errorf("%s: no rule or tagged scenario to split", file)}}
+	}
+
+	build := func(selected func(*messages.Scenario) bool, rules map[*messages.Rule]bool) ([]byte, bool) {
+		sections := [][]string{featureHeader(doc)}
+		if scopes[0].background != nil {
+			sections = append(sections, blockLines(doc, scopes[0].background.Location.Line))
+		}
+		found := false
+		for _, s := range scopes {
+			scenarios := [][]string{}
+			for _, scenario := range s.scenarios {
+				if selected(scenario) {
+					scenarios = append(scenarios, blockLines(doc, scenario.Location.Line))
+				}
+			}
+			found = found || len(scenarios) > 0
+			if s.rule != nil && (len(scenarios) > 0 || rules[s.rule]) {
+				found = true
+				sections = append(sections, ruleHeader(doc, s))
+			}
+			sections = append(sections, scenarios...)
+		}
+		return joinSections(sections), found
+	}
+
+	files := []generatedFile{}
+	base := strings.TrimSuffix(file, filepath.Ext(file))
+	names := placeholders{}
+	for _, g := range groups {
+		path := base + "-" + names.name(slug(g.name)) + filepath.Ext(file)
+		if _, err := os.Stat(path); err == nil {
+			return ProcessFileResults{{File: path, Status: StatusError, Err: fmt.Errorf(`%s: file already exists`, path)}}
+		}
+		content, _ := build(func(scenario *messages.Scenario) bool { return g.scenarios[scenario] }, g.rules)
+		files = append(files, generatedFile{path, content})
+	}
+	emptyRules := map[*messages.Rule]bool{}
+	for _, s := range scopes[1:] {
+		emptyRules[s.rule] = len(tags) > 0 && len(s.scenarios) == 0
+	}
+	content, found := build(func(scenario *messages.Scenario) bool { return !grouped[scenario] }, emptyRules)
+	if !found {
+		content = nil
+	}
+	return f.writeFiles(source, append(files, generatedFile{file, content}))
+}
+
+// Merge combines features of files or folders in the file output, each feature becomes a rule
+// with its tags, its description, its background and its scenarios while rules of a feature
+// are copied as they are. A background or a description shared by all features is moved
+// to the merged feature, merged files are removed. output can't be an existing file unless
+// it's one of the merged files
+func (f FileManager) Merge(name string, output string, paths []string, extensions []string) ProcessFileResults {
+	files, results := collectFiles(paths, extensions)
+	if len(results) > 0 {
+		return results
+	}
+	if len(files) == 0 {
+		return ProcessFileResults{{File: output, Status: StatusError, Err: fmt.Errorf("%s: no feature to merge", output)}}
+	}
+	if _, err := os.Stat(output); err == nil {
+		merged := false
+		for _, file := range files {
+			merged = merged || filepath.Clean(file.path) == filepath.Clean(output)
+		}
+		if !merged {
+			return ProcessFileResults{{File: output, Status: StatusError, Err: fmt.Errorf(`%s: file already exists`, output)}}
+		}
+	}
+	sources := []sourceDocument{}
+	for _, file := range files {
+		source, err := readSourceDocument(file.path)
+		if err != nil {
+			return ProcessFileResults{{File: file.path, Status: StatusError, Err: err}}
+		}
+		feature := source.doc.Gherkin.Feature
+		switch {
+		case feature == nil:
+			return ProcessFileResults{{File: file.path, Status: StatusError, Err: fmt.Errorf("%s: no feature to merge", file.path)}}
+		case len(sources) > 0 && feature.Language != sources[0].doc.Gherkin.Feature.Language:
+			return ProcessFileResults{{File: file.path, Status: StatusError, Err: fmt.Errorf(`%s: language "%s" differs from language "%s" of other features`, file.path, feature.Language, sources[0].doc.Gherkin.Feature.Language)}}
+		}
+		sources = append(sources, source)
+	}
+
+	background := func(doc *Document) []string {
+		if s := doc.scopes()[0]; s.background != nil {
+			return blockLines(doc, s.background.Location.Line)
+		}
+		return []string{}
+	}
+	sharedBackground, sharedDescription := background(sources[0].doc), featureDescription(sources[0].doc)
+	for _, source := range sources[1:] {
+		if !sameLines(sharedBackground, background(source.doc)) {
+			sharedBackground = []string{}
+		}
+		if !sameLines(sharedDescription, featureDescription(source.doc)) {
+			sharedDescription = []string{}
+		}
+	}
+
+	language := sources[0].doc.Gherkin.Feature.Language
+	header := []string{}
+	if language != gherkin.DefaultDialect {
+		header = append(header, "# language: "+language)
+	}
+	header = append(header, sources[0].doc.keyword("feature", f.options.keywords)+": "+name)
+	sections := [][]string{append(header, sharedDescription...), sharedBackground}
+	for _, source := range sources {
+		doc := source.doc
+		feature := doc.Gherkin.Feature
+		scopes := doc.scopes()
+		description := featureDescription(doc)
+		if sameLines(description, sharedDescription) {
+			description = []string{}
+		}
+		ownBackground := len(sharedBackground) == 0 && scopes[0].background != nil
+		tags := []string{}
+		for _, tag := range feature.Tags {
+			tags = append(tags, tag.Name)
+		}
+		if len(scopes) > 1 {
+			if len(scopes[0].scenarios) > 0 || ownBackground || len(description) > 0 {
+				return ProcessFileResults{{File: doc.File, Status: StatusError, Err: fmt.Errorf("%s: a feature with rules can only be merged when its description and its background are shared and when all its scenarios are in rules", doc.File)}}
+			}
+			for _, s := range scopes[1:] {
+				rule := ruleHeader(doc, s)
+				if len(tags) > 0 {
+					rule = append([]string{strings.Join(tags, " ")}, rule...)
+				}
+				sections = append(sections, rule)
+				for _, scenario := range s.scenarios {
+					sections = append(sections, blockLines(doc, scenario.Location.Line))
+				}
+			}
+			continue
+		}
+		rule := []string{}
+		if len(tags) > 0 {
+			rule = append(rule, strings.Join(tags, " "))
+		}
+		rule = append(rule, doc.keyword("rule", f.options.keywords)+": "+feature.Name)
+		sections = append(sections, append(rule, description...))
+		if ownBackground {
+			sections = append(sections, background(doc))
+		}
+		for _, scenario := range scopes[0].scenarios {
+			sections = append(sections, blockLines(doc, scenario.Location.Line))
+		}
+	}
+
+	generated := []generatedFile{{output, joinSections(sections)}}
+	for _, source := range sources {
+		if filepath.Clean(source.doc.File) != filepath.Clean(output) {
+			generated = append(generated, generatedFile{source.doc.File, nil})
+		}
+	}
+	return f.writeFiles(sources[0], generated)
+}
+
+// sameLines compares lines ignoring their indentation
+func sameLines(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimSpace(a[i]) != strings.TrimSpace(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// featureHeader returns lines of a document preceding its first background, scenario or rule
+func featureHeader(doc *Document) []string {
+	end := int64(len(doc.Lines))
+	if lines := doc.elementLines(); len(lines) > 0 {
+		end = doc.blockStart(lines[0]) - 1
+	}
+	return trimLines(doc, 1, end)
+}
+
+// featureDescription returns lines of a document between its feature line and its first element
+func featureDescription(doc *Document) []string {
+	header := featureHeader(doc)
+	start := int(doc.Gherkin.Feature.Location.Line)
+	if start >= len(header) {
+		return []string{}
+	}
+	return header[start:]
+}
+
+// ruleHeader returns lines of a rule preceding its first scenario, the background is included
+func ruleHeader(doc *Document, s scope) []string {
+	end := int64(len(doc.Lines))
+	for _, line := range doc.elementLines() {
+		if line > s.rule.Location.Line && (s.background == nil || line != s.background.Location.Line) {
+			end = doc.blockStart(line) - 1
+			break
+		}
+	}
+	return trimLines(doc, doc.blockStart(s.rule.Location.Line), end)
+}
+
+// blockLines returns lines of the element starting at a line
+func blockLines(doc *Document, line int64) []string {
+	start, end := doc.block(line)
+	return trimLines(doc, start, end)
+}
+
+// trimLines returns lines between two lines, trailing empty lines are excluded
+func trimLines(doc *Document, start int64, end int64) []string {
+	for end >= start && strings.TrimSpace(doc.Line(end)) == "" {
+		end--
+	}
+	if end < start {
+		return []string{}
+	}
+	return append([]string{}, doc.Lines[start-1:end]...)
+}
+
+// joinSections joins sections of lines with an empty line
+func joinSections(sections [][]string) []byte {
+	parts := []string{}
+	for _, section := range sections {
+		if len(section) > 0 {
+			parts = append(parts, strings.Join(section, "\n"))
+		}
+	}
+	return []byte(strings.Join(parts, "\n\n") + "\n")
+}
+
+func hasTag(tags []*messages.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// slug converts a name to a string usable in a file name
+func slug(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteRune('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
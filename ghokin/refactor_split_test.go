@@ -0,0 +1,323 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerSplit(t *testing.T) {
+	content := "# language: fr\r\n" +
+		"@shop\r\n" +
+		"Fonctionnalité: Boutique\r\n" +
+		"  Une description\r\n" +
+		"\r\n" +
+		"  Contexte:\r\n" +
+		"    Soit un utilisateur\r\n" +
+		"\r\n" +
+		"  @smoke\r\n" +
+		"  Scénario: Hors règle\r\n" +
+		"    Soit une chose\r\n" +
+		"\r\n" +
+		"  Règle: Achat de livres\r\n" +
+		"\r\n" +
+		"    Contexte:\r\n" +
+		"      Soit un livre\r\n" +
+		"\r\n" +
+		"    Scénario: Acheter\r\n" +
+		"      Quand il achète\r\n" +
+		"\r\n" +
+		"  Règle: Vente\r\n" +
+		"\r\n" +
+		"    @smoke\r\n" +
+		"    Scénario: Vendre\r\n" +
+		"      Quand il vend\r\n"
+
+	type scenario struct {
+		tags     []string
+		expected map[string]string
+	}
+
+	scenarios := []scenario{
+		{
+			[]string{},
+			map[string]string{
+				"/tmp/ghokin/shop.feature": "# language: fr\r\n" +
+					"@shop\r\n" +
+					"Fonctionnalité: Boutique\r\n" +
+					"  Une description\r\n" +
+					"\r\n" +
+					"  Contexte:\r\n" +
+					"    Soit un utilisateur\r\n" +
+					"\r\n" +
+					"  @smoke\r\n" +
+					"  Scénario: Hors règle\r\n" +
+					"    Soit une chose\r\n",
+				"/tmp/ghokin/shop-achat-de-livres.feature": "# language: fr\r\n" +
+					"@shop\r\n" +
+					"Fonctionnalité: Boutique\r\n" +
+					"  Une description\r\n" +
+					"\r\n" +
+					"  Contexte:\r\n" +
+					"    Soit un utilisateur\r\n" +
+					"\r\n" +
+					"  Règle: Achat de livres\r\n" +
+					"\r\n" +
+					"    Contexte:\r\n" +
+					"      Soit un livre\r\n" +
+					"\r\n" +
+					"    Scénario: Acheter\r\n" +
+					"      Quand il achète\r\n",
+				"/tmp/ghokin/shop-vente.feature": "# language: fr\r\n" +
+					"@shop\r\n" +
+					"Fonctionnalité: Boutique\r\n" +
+					"  Une description\r\n" +
+					"\r\n" +
+					"  Contexte:\r\n" +
+					"    Soit un utilisateur\r\n" +
+					"\r\n" +
+					"  Règle: Vente\r\n" +
+					"\r\n" +
+					"    @smoke\r\n" +
+					"    Scénario: Vendre\r\n" +
+					"      Quand il vend\r\n",
+			},
+		},
+		{
+			[]string{"@smoke"},
+			map[string]string{
+				"/tmp/ghokin/shop.feature": "# language: fr\r\n" +
+					"@shop\r\n" +
+					"Fonctionnalité: Boutique\r\n" +
+					"  Une description\r\n" +
+					"\r\n" +
+					"  Contexte:\r\n" +
+					"    Soit un utilisateur\r\n" +
+					"\r\n" +
+					"  Règle: Achat de livres\r\n" +
+					"\r\n" +
+					"    Contexte:\r\n" +
+					"      Soit un livre\r\n" +
+					"\r\n" +
+					"    Scénario: Acheter\r\n" +
+					"      Quand il achète\r\n",
+				"/tmp/ghokin/shop-smoke.feature": "# language: fr\r\n" +
+					"@shop\r\n" +
+					"Fonctionnalité: Boutique\r\n" +
+					"  Une description\r\n" +
+					"\r\n" +
+					"  Contexte:\r\n" +
+					"    Soit un utilisateur\r\n" +
+					"\r\n" +
+					"  @smoke\r\n" +
+					"  Scénario: Hors règle\r\n" +
+					"    Soit une chose\r\n" +
+					"\r\n" +
+					"  Règle: Vente\r\n" +
+					"\r\n" +
+					"    @smoke\r\n" +
+					"    Scénario: Vendre\r\n" +
+					"      Quand il vend\r\n",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+		assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+		assert.NoError(t, os.WriteFile("/tmp/ghokin/shop.feature", []byte(content), 0o777))
+
+		results := NewFileManager(2, map[string]string{}, WithDryRun(true)).Split("/tmp/ghokin/shop.feature", s.tags)
+		assert.Len(t, results, len(s.expected))
+		assert.Empty(t, results.Errors())
+		b, err := os.ReadFile("/tmp/ghokin/shop.feature")
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+
+		results = NewFileManager(2, map[string]string{}).Split("/tmp/ghokin/shop.feature", s.tags)
+		assert.Len(t, results, len(s.expected))
+		assert.Empty(t, results.Errors())
+		for file, expected := range s.expected {
+			b, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(b))
+		}
+	}
+}
+
+func TestFileManagerSplitErrors(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/shop.feature", []byte("Feature: Shop\n  Rule: Sell\n    Scenario: Sell\n      When he sells\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/shop-sell.feature", []byte(""), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).Split("/tmp/ghokin/shop.feature", []string{})
+	assert.Equal(t, []error{errors.New("/tmp/ghokin/shop-sell.feature: file already exists")}, results.Errors())
+
+	results = NewFileManager(2, map[string]string{}).Split("/tmp/ghokin/shop.feature", []string{"@whatever"})
+	assert.Equal(t, []error{errors.New("/tmp/ghokin/shop.feature: no rule or tagged scenario to split")}, results.Errors())
+}
+
+func TestFileManagerMerge(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin/features", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/features/buy.feature", []byte("@buy\nFeature: Buy\n  A description\n\n  Background:\n    Given a user\n\n  Scenario: Buy\n    When he buys\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/features/sell.feature", []byte("Feature: Sell\n\n  # A comment\n  Scenario: Sell\n    When he sells\n"), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).Merge("Shop", "/tmp/ghokin/shop.feature", []string{"/tmp/ghokin/features"}, []string{"feature"})
+	assert.Len(t, results, 3)
+	assert.Empty(t, results.Errors())
+	b, err := os.ReadFile("/tmp/ghokin/shop.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "Feature: Shop\n"+
+		"\n"+
+		"  @buy\n"+
+		"  Rule: Buy\n"+
+		"  A description\n"+
+		"\n"+
+		"    Background:\n"+
+		"      Given a user\n"+
+		"\n"+
+		"    Scenario: Buy\n"+
+		"      When he buys\n"+
+		"\n"+
+		"  Rule: Sell\n"+
+		"\n"+
+		"    # A comment\n"+
+		"    Scenario: Sell\n"+
+		"      When he sells\n", string(b))
+	_, err = os.Stat("/tmp/ghokin/features/buy.feature")
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat("/tmp/ghokin/features/sell.feature")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileManagerMergeSplitFeatures(t *testing.T) {
+	content := "@shop\n" +
+		"Feature: Shop\n" +
+		"  A description\n" +
+		"\n" +
+		"  Background:\n" +
+		"    Given a user\n" +
+		"\n" +
+		"  Rule: Buy\n" +
+		"\n" +
+		"    Scenario: Buy\n" +
+		"      When he buys\n" +
+		"\n" +
+		"  Rule: Sell\n" +
+		"\n" +
+		"    Scenario: Sell\n" +
+		"      When he sells\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/shop.feature", []byte(content), 0o777))
+
+	fileManager := NewFileManager(2, map[string]string{})
+	assert.Empty(t, fileManager.Split("/tmp/ghokin/shop.feature", []string{}).Errors())
+	assert.Empty(t, fileManager.Merge("Shop", "/tmp/ghokin/shop.feature", []string{"/tmp/ghokin/shop-buy.feature", "/tmp/ghokin/shop-sell.feature"}, []string{"feature"}).Errors())
+	b, err := os.ReadFile("/tmp/ghokin/shop.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "Feature: Shop\n"+
+		"  A description\n"+
+		"\n"+
+		"  Background:\n"+
+		"    Given a user\n"+
+		"\n"+
+		"  @shop\n"+
+		"  Rule: Buy\n"+
+		"\n"+
+		"    Scenario: Buy\n"+
+		"      When he buys\n"+
+		"\n"+
+		"  @shop\n"+
+		"  Rule: Sell\n"+
+		"\n"+
+		"    Scenario: Sell\n"+
+		"      When he sells\n", string(b))
+}
+
+func TestFileManagerMergeErrors(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/rule.feature", []byte("Feature: Rule\n  Scenario: A scenario\n  Rule: A rule\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/fr.feature", []byte("# language: fr\nFonctionnalité: Fr\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/en.feature", []byte("Feature: En\n"), 0o777))
+
+	type scenario struct {
+		paths []string
+		err   error
+	}
+
+	scenarios := []scenario{
+		{
+			[]string{"/tmp/ghokin/rule.feature"},
+			errors.New("/tmp/ghokin/rule.feature: a feature with rules can only be merged when its description and its background are shared and when all its scenarios are in rules"),
+		},
+		{
+			[]string{"/tmp/ghokin/en.feature", "/tmp/ghokin/fr.feature"},
+			errors.New(`/tmp/ghokin/fr.feature: language "fr" differs from language "en" of other features`),
+		},
+	}
+
+	for _, s := range scenarios {
+		results := NewFileManager(2, map[string]string{}).Merge("Shop", "/tmp/ghokin/shop.feature", s.paths, []string{"feature"})
+		assert.Equal(t, []error{s.err}, results.Errors())
+	}
+
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/out.feature", []byte("Feature: Precious\n"), 0o777))
+	results := NewFileManager(2, map[string]string{}).Merge("Shop", "/tmp/ghokin/out.feature", []string{"/tmp/ghokin/en.feature"}, []string{"feature"})
+	assert.Equal(t, []error{errors.New("/tmp/ghokin/out.feature: file already exists")}, results.Errors())
+	for file, content := range map[string]string{"/tmp/ghokin/out.feature": "Feature: Precious\n", "/tmp/ghokin/en.feature": "Feature: En\n"} {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	}
+}
+
+func TestFileManagerMergeInMergedFile(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/buy.feature", []byte("Feature: Buy\n  Scenario: Buy\n    When he buys\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/sell.feature", []byte("Feature: Sell\n  Scenario: Sell\n    When he sells\n"), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).Merge("Shop", "/tmp/ghokin/./buy.feature", []string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Empty(t, results.Errors())
+	b, err := os.ReadFile("/tmp/ghokin/buy.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, "Feature: Shop\n"+
+		"\n"+
+		"  Rule: Buy\n"+
+		"\n"+
+		"    Scenario: Buy\n"+
+		"      When he buys\n"+
+		"\n"+
+		"  Rule: Sell\n"+
+		"\n"+
+		"    Scenario: Sell\n"+
+		"      When he sells\n", string(b))
+	_, err = os.Stat("/tmp/ghokin/sell.feature")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileManagerMergeWriteError(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/buy.feature", []byte("Feature: Buy\n  Scenario: Buy\n    When he buys\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/sell.feature", []byte("Feature: Sell\n  Scenario: Sell\n    When he sells\n"), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).Merge("Shop", "/tmp/ghokin/missing/shop.feature", []string{"/tmp/ghokin"}, []string{"feature"})
+	assert.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, "/tmp/ghokin/missing/shop.feature", results[0].File)
+	entries, err := os.ReadDir("/tmp/ghokin")
+	assert.NoError(t, err)
+	files := []string{}
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	assert.Equal(t, []string{"buy.feature", "sell.feature"}, files)
+}
@@ -0,0 +1,92 @@
+package ghokin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// RewriteTags renames tags found in renames and removes tags found in removals
+// on features, rules, scenarios and examples of files or folders, duplicated tags
+// created by a rename on the same element are removed, other ones are kept as they
+// are, changed files are formatted
+func (f FileManager) RewriteTags(renames map[string]string, removals []string, paths []string, extensions []string) (ProcessFileResults, error) {
+	removed := map[string]bool{}
+	for _, tag := range removals {
+		if err := checkTag(tag); err != nil {
+			return ProcessFileResults{}, err
+		}
+		removed[tag] = true
+	}
+	for from, to := range renames {
+		for _, tag := range []string{from, to} {
+			if err := checkTag(tag); err != nil {
+				return ProcessFileResults{}, err
+			}
+		}
+	}
+	return f.refactor(paths, extensions, func(doc *Document) ([]LineEdit, error) {
+		replacements := map[int64]map[*messages.Tag]string{}
+		for _, element := range extractTaggedElements(doc) {
+			defined := map[string]bool{}
+			renamed := map[string]bool{}
+			for _, tag := range element.tags {
+				name, ok := renames[tag.Name]
+				if !ok {
+					name = tag.Name
+				}
+				if removed[tag.Name] || (ok || renamed[name]) && defined[name] {
+					name = ""
+				}
+				defined[name] = true
+				renamed[name] = renamed[name] || ok
+				if name == tag.Name {
+					continue
+				}
+				if _, ok := replacements[tag.Location.Line]; !ok {
+					replacements[tag.Location.Line] = map[*messages.Tag]string{}
+				}
+				replacements[tag.Location.Line][tag] = name
+			}
+		}
+		edits := []LineEdit{}
+		for number, tags := range replacements {
+			edit, ok := replaceTags(doc, number, tags)
+			if !ok {
+				return []LineEdit{}, fmt.Errorf("%s:%d: tags can't be found at their location", doc.File, number)
+			}
+			edits = append(edits, edit)
+		}
+		return edits, nil
+	}), nil
+}
+
+// replaceTags replaces several tags of a line starting
+// with the last one to keep columns of others valid
+func replaceTags(doc *Document, number int64, replacements map[*messages.Tag]string) (LineEdit, bool) {
+	tags := []*messages.Tag{}
+	for tag := range replacements {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Location.Column > tags[j].Location.Column
+	})
+	line := []rune(doc.Line(number))
+	for _, tag := range tags {
+		var ok bool
+		if line, ok = replaceTag(line, tag, replacements[tag]); !ok {
+			return LineEdit{}, false
+		}
+	}
+	return tagLineEdit(int(number), string(line)), true
+}
+
+func checkTag(tag string) error {
+	if len(tag) < 2 || !strings.HasPrefix(tag, "@") || strings.IndexFunc(tag, unicode.IsSpace) != -1 {
+		return fmt.Errorf(`tag "%s" must start with @ and can't contain spaces`, tag)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package ghokin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerRewriteTags(t *testing.T) {
+	content := "@old @obsolete\n" +
+		"Feature: Tags\n" +
+		"\n" +
+		"  @obsolete @a @a\n" +
+		"  Rule: A rule\n" +
+		"\n" +
+		"    @new @old @keep\n" +
+		"    Scenario Outline: An outline\n" +
+		"      Given a <thing>\n" +
+		"\n" +
+		"      @old @obsolete @new\n" +
+		"      Examples:\n" +
+		"        | thing |\n" +
+		"        | book  |\n"
+
+	rewritten := "@new\n" +
+		"Feature: Tags\n" +
+		"\n" +
+		"  @a @a\n" +
+		"  Rule: A rule\n" +
+		"\n" +
+		"    @new @keep\n" +
+		"    Scenario Outline: An outline\n" +
+		"      Given a <thing>\n" +
+		"\n" +
+		"      @new\n" +
+		"      Examples:\n" +
+		"        | thing |\n" +
+		"        | book  |\n"
+
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/tags.feature", []byte(content), 0o777))
+
+	results, err := NewFileManager(2, map[string]string{}, WithDryRun(true)).RewriteTags(map[string]string{"@old": "@new"}, []string{"@obsolete"}, []string{"/tmp/ghokin/tags.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, unifiedDiff("/tmp/ghokin/tags.feature", "/tmp/ghokin/tags.feature", []byte(content), []byte(rewritten)), results[0].Diff)
+
+	results, err = NewFileManager(2, map[string]string{}).RewriteTags(map[string]string{"@old": "@new"}, []string{"@obsolete"}, []string{"/tmp/ghokin/tags.feature"}, []string{"feature"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	b, err := os.ReadFile("/tmp/ghokin/tags.feature")
+	assert.NoError(t, err)
+	assert.Equal(t, rewritten, string(b))
+}
+
+func TestFileManagerRewriteTagsWithInvalidTags(t *testing.T) {
+	type scenario struct {
+		renames  map[string]string
+		removals []string
+		err      error
+	}
+
+	scenarios := []scenario{
+		{
+			map[string]string{},
+			[]string{"obsolete"},
+			errors.New(`tag "obsolete" must start with @ and can't contain spaces`),
+		},
+		{
+			map[string]string{"@old": "@"},
+			[]string{},
+			errors.New(`tag "@" must start with @ and can't contain spaces`),
+		},
+		{
+			map[string]string{"@a b": "@new"},
+			[]string{},
+			errors.New(`tag "@a b" must start with @ and can't contain spaces`),
+		},
+	}
+
+	for _, s := range scenarios {
+		_, err := NewFileManager(2, map[string]string{}).RewriteTags(s.renames, s.removals, []string{"fixtures/feature.feature"}, []string{"feature"})
+		assert.Equal(t, s.err, err)
+	}
+}
@@ -0,0 +1,138 @@
+package ghokin
+
+import (
+	"sort"
+
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// stepKinds lists step kinds in the order they are reported
+var stepKinds = []string{"given", "when", "then", "and", "but", "*"}
+
+// Stats describes the content of a suite of feature files
+type Stats struct {
+	Files            int            `json:"files"`
+	Features         int            `json:"features"`
+	Rules            int            `json:"rules"`
+	Scenarios        int            `json:"scenarios"`
+	Outlines         int            `json:"outlines"`
+	ExampleRows      int            `json:"exampleRows"`
+	Steps            map[string]int `json:"steps"`
+	Tags             []TagCount     `json:"tags"`
+	LargestScenarios []ScenarioSize `json:"largestScenarios"`
+	Languages        map[string]int `json:"languages"`
+}
+
+// TagCount is the number of elements a tag is defined on
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// ScenarioSize is the number of steps of a scenario
+type ScenarioSize struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Name  string `json:"name"`
+	Steps int    `json:"steps"`
+}
+
+// StatsResults gathers statistics and errors of files that can't be parsed
+type StatsResults struct {
+	Stats  Stats
+	Errors []error
+}
+
+// Stats computes statistics on files or folders, steps are counted by keyword kind with
+// * for steps using a star, tags are sorted by frequency and at most top largest scenarios
+// are reported
+func (f FileManager) Stats(paths []string, extensions []string, top int) StatsResults {
+	docs, errs := loadDocuments(paths, extensions, f.options.jobs)
+	return StatsResults{Stats: computeStats(docs, top), Errors: errs}
+}
+
+func computeStats(docs []*Document, top int) Stats {
+	stats := Stats{
+		Steps:            map[string]int{},
+		Tags:             []TagCount{},
+		LargestScenarios: []ScenarioSize{},
+		Languages:        map[string]int{},
+	}
+	for _, kind := range stepKinds {
+		stats.Steps[kind] = 0
+	}
+	tags := map[string]int{}
+	for _, doc := range docs {
+		stats.Files++
+		feature := doc.Gherkin.Feature
+		if feature == nil {
+			continue
+		}
+		stats.Features++
+		stats.Languages[feature.Language]++
+		for _, tag := range extractTags(doc) {
+			tags[tag.Name]++
+		}
+		for _, s := range doc.scopes() {
+			if s.rule != nil {
+				stats.Rules++
+			}
+			if s.background != nil {
+				countSteps(doc, s.background.Steps, stats.Steps)
+			}
+			for _, scenario := range s.scenarios {
+				if isOutline(doc, scenario) {
+					stats.Outlines++
+				} else {
+					stats.Scenarios++
+				}
+				for _, examples := range scenario.Examples {
+					stats.ExampleRows += len(examples.TableBody)
+				}
+				countSteps(doc, scenario.Steps, stats.Steps)
+				stats.LargestScenarios = append(stats.LargestScenarios, ScenarioSize{
+					File:  doc.File,
+					Line:  int(scenario.Location.Line),
+					Name:  scenario.Name,
+					Steps: len(scenario.Steps),
+				})
+			}
+		}
+	}
+	for tag, count := range tags {
+		stats.Tags = append(stats.Tags, TagCount{tag, count})
+	}
+	sort.Slice(stats.Tags, func(i, j int) bool {
+		if stats.Tags[i].Count != stats.Tags[j].Count {
+			return stats.Tags[i].Count > stats.Tags[j].Count
+		}
+		return stats.Tags[i].Tag < stats.Tags[j].Tag
+	})
+	sort.SliceStable(stats.LargestScenarios, func(i, j int) bool {
+		return stats.LargestScenarios[i].Steps > stats.LargestScenarios[j].Steps
+	})
+	if top >= 0 && len(stats.LargestScenarios) > top {
+		stats.LargestScenarios = stats.LargestScenarios[:top]
+	}
+	return stats
+}
+
+// countSteps counts steps by keyword kind
+func countSteps(doc *Document, steps []*messages.Step, counts map[string]int) {
+	for _, step := range steps {
+		kind := "*"
+		for _, k := range stepKinds[:5] {
+			for _, keyword := range doc.Dialect.Keywords[k] {
+				if keyword == step.Keyword && keyword != "* " {
+					kind = k
+				}
+			}
+		}
+		counts[kind]++
+	}
+}
+
+// StepKinds returns step kinds in the order they are reported
+func StepKinds() []string {
+	return append([]string{}, stepKinds...)
+}
@@ -0,0 +1,66 @@
+package ghokin
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileManagerStats(t *testing.T) {
+	assert.NoError(t, os.RemoveAll("/tmp/ghokin"))
+	assert.NoError(t, os.MkdirAll("/tmp/ghokin", 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/a.feature", []byte("@smoke\n"+
+		"Feature: A\n"+
+		"  Background:\n"+
+		"    Given a user\n"+
+		"\n"+
+		"  @smoke @slow\n"+
+		"  Scenario Outline: An outline\n"+
+		"    Given a <thing>\n"+
+		"    When he buys\n"+
+		"    Then he pays\n"+
+		"\n"+
+		"    @wip\n"+
+		"    Examples:\n"+
+		"      | thing |\n"+
+		"      | book  |\n"+
+		"      | shelf |\n"+
+		"\n"+
+		"  Rule: A rule\n"+
+		"    Scenario: A scenario\n"+
+		"      * a thing\n"+
+		"      And another thing\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/b.feature", []byte("# language: fr\n"+
+		"Fonctionnalité: B\n"+
+		"  @slow\n"+
+		"  Scénario: Un scénario\n"+
+		"    Soit une chose\n"+
+		"    Mais pas une autre\n"+
+		"\n"+
+		"  Plan du scénario: Un plan sans exemples\n"+
+		"    Soit une <chose>\n"), 0o777))
+	assert.NoError(t, os.WriteFile("/tmp/ghokin/c.feature", []byte("Whatever"), 0o777))
+
+	results := NewFileManager(2, map[string]string{}).Stats([]string{"/tmp/ghokin"}, []string{"feature"}, 2)
+	assert.Len(t, results.Errors, 1)
+	assert.Equal(t, Stats{
+		Files:       2,
+		Features:    2,
+		Rules:       1,
+		Scenarios:   2,
+		Outlines:    2,
+		ExampleRows: 2,
+		Steps:       map[string]int{"given": 4, "when": 1, "then": 1, "and": 1, "but": 1, "*": 1},
+		Tags: []TagCount{
+			{"@slow", 2},
+			{"@smoke", 2},
+			{"@wip", 1},
+		},
+		LargestScenarios: []ScenarioSize{
+			{"/tmp/ghokin/a.feature", 7, "An outline", 3},
+			{"/tmp/ghokin/a.feature", 19, "A scenario", 2},
+		},
+		Languages: map[string]int{"en": 1, "fr": 1},
+	}, results.Stats)
+}
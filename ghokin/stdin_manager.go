@@ -2,22 +2,22 @@ package ghokin
 
 import (
 	"io"
-
-	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
 )
 
 // StdinManager handles transformation from stdin
 type StdinManager struct {
 	indent  int
 	aliases aliases
+	options options
 }
 
 // NewStdinManager creates a brand new StdinManager, it requires indentation values and aliases defined
-// as a shell commands in comments
-func NewStdinManager(indent int, aliases map[string]string) StdinManager {
+// as a shell commands in comments, optional behaviours are enabled through options
+func NewStdinManager(indent int, aliases map[string]string, opts ...Option) StdinManager {
 	return StdinManager{
 		indent,
 		aliases,
+		newOptions(opts),
 	}
 }
 
@@ -27,16 +27,5 @@ func (s StdinManager) Transform(reader io.Reader) ([]byte, error) {
 	if err != nil {
 		return []byte{}, err
 	}
-	contentTransformer := &transformer.ContentTransformer{}
-	contentTransformer.DetectSettings(content)
-	content = contentTransformer.Prepare(content)
-	section, err := extractSections(content)
-	if err != nil {
-		return []byte{}, err
-	}
-	content, err = transform(section, s.indent, s.aliases)
-	if err != nil {
-		return []byte{}, err
-	}
-	return contentTransformer.Restore(content), nil
+	return format(content, s.indent, s.aliases, s.options)
 }
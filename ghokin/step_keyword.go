@@ -0,0 +1,87 @@
+package ghokin
+
+import (
+	"fmt"
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v28"
+	messages "github.com/cucumber/messages/go/v24"
+)
+
+// StepKeywordStyle defines how step keywords are rewritten when formatting
+type StepKeywordStyle int
+
+const (
+	// StepKeywordPreserve keeps step keywords as they are
+	StepKeywordPreserve StepKeywordStyle = iota
+	// StepKeywordConjunction replaces a keyword repeating the type of the previous step with And
+	StepKeywordConjunction
+	// StepKeywordExplicit replaces And and But with the keyword of the type of the previous step
+	StepKeywordExplicit
+)
+
+// String returns a human readable step keyword style
+func (s StepKeywordStyle) String() string {
+	switch s {
+	case StepKeywordConjunction:
+		return "and"
+	case StepKeywordExplicit:
+		return "explicit"
+	default:
+		return "preserve"
+	}
+}
+
+// ParseStepKeywordStyle converts a style name to a StepKeywordStyle
+func ParseStepKeywordStyle(style string) (StepKeywordStyle, error) {
+	for _, s := range []StepKeywordStyle{StepKeywordPreserve, StepKeywordConjunction, StepKeywordExplicit} {
+		if strings.EqualFold(s.String(), style) {
+			return s, nil
+		}
+	}
+	return StepKeywordPreserve, fmt.Errorf(`step keyword style "%s" doesn't exist, it must be one of preserve, and or explicit`, style)
+}
+
+// stepKeywordRewriter rewrites step keywords according to a style, it tracks
+// the type of the previous step which must be reset on every new step container
+type stepKeywordRewriter struct {
+	style    StepKeywordStyle
+	keywords Keywords
+	previous messages.StepKeywordType
+}
+
+func (s *stepKeywordRewriter) reset() {
+	s.previous = messages.StepKeywordType_UNKNOWN
+}
+
+// rewrite returns copies of step tokens with rewritten keywords, * steps are never rewritten
+func (s *stepKeywordRewriter) rewrite(tokens []*gherkin.Token) []*gherkin.Token {
+	rewritten := []*gherkin.Token{}
+	for _, token := range tokens {
+		t := *token
+		kind := t.KeywordType
+		switch kind {
+		case messages.StepKeywordType_CONJUNCTION, messages.StepKeywordType_UNKNOWN:
+			kind = s.previous
+			if s.style == StepKeywordExplicit && t.KeywordType == messages.StepKeywordType_CONJUNCTION {
+				t.Keyword = s.keywords.stepKeyword(t.GherkinDialect, kind, t.Keyword)
+			}
+		default:
+			if s.style == StepKeywordConjunction && kind == s.previous {
+				t.Keyword = s.keywords.stepKeyword(t.GherkinDialect, messages.StepKeywordType_CONJUNCTION, t.Keyword)
+			}
+		}
+		s.previous = kind
+		rewritten = append(rewritten, &t)
+	}
+	return rewritten
+}
+
+// stepKeyword returns the canonical keyword of a step type in a language,
+// def is returned when no keyword matches
+func (k Keywords) stepKeyword(language string, kind messages.StepKeywordType, def string) string {
+	if keyword := k.keyword(language, stepKeywordKinds[kind]); keyword != "" {
+		return keyword
+	}
+	return def
+}
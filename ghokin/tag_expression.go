@@ -0,0 +1,210 @@
+package ghokin
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TagExpression is a cucumber tag expression like "@smoke and not @wip"
+type TagExpression struct {
+	expression string
+	root       tagNode
+}
+
+type tagNode interface {
+	evaluate(tags map[string]bool) bool
+}
+
+type tagLiteral string
+
+func (n tagLiteral) evaluate(tags map[string]bool) bool {
+	return tags[string(n)]
+}
+
+type tagNot struct {
+	node tagNode
+}
+
+func (n tagNot) evaluate(tags map[string]bool) bool {
+	return !n.node.evaluate(tags)
+}
+
+type tagAnd struct {
+	left, right tagNode
+}
+
+func (n tagAnd) evaluate(tags map[string]bool) bool {
+	return n.left.evaluate(tags) && n.right.evaluate(tags)
+}
+
+type tagOr struct {
+	left, right tagNode
+}
+
+func (n tagOr) evaluate(tags map[string]bool) bool {
+	return n.left.evaluate(tags) || n.right.evaluate(tags)
+}
+
+// String returns the expression as it was parsed
+func (e TagExpression) String() string {
+	return e.expression
+}
+
+// Match returns true when tags satisfy the expression
+func (e TagExpression) Match(tags []string) bool {
+	if e.root == nil {
+		return true
+	}
+	set := map[string]bool{}
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return e.root.evaluate(set)
+}
+
+// ParseTagExpression parses a cucumber tag expression made of tags, and, or, not
+// and parentheses, a backslash escapes a space, a parenthesis or a backslash in a tag,
+// an empty expression matches everything
+func ParseTagExpression(expression string) (TagExpression, error) {
+	tokens, err := tokenizeTagExpression(expression)
+	if err != nil {
+		return TagExpression{}, fmt.Errorf(`tag expression "%s" is invalid: %s`, expression, err)
+	}
+	if len(tokens) == 0 {
+		return TagExpression{expression: expression}, nil
+	}
+	p := tagParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.position < len(tokens) {
+		err = fmt.Errorf(`unexpected "%s"`, tokens[p.position].value)
+	}
+	if err != nil {
+		return TagExpression{}, fmt.Errorf(`tag expression "%s" is invalid: %s`, expression, err)
+	}
+	return TagExpression{expression, root}, nil
+}
+
+type tagToken struct {
+	value    string
+	operator bool
+}
+
+func tokenizeTagExpression(expression string) ([]tagToken, error) {
+	tokens := []tagToken{}
+	var b strings.Builder
+	escaped := false
+	tag := false
+	flush := func() {
+		if !tag {
+			return
+		}
+		value := b.String()
+		tokens = append(tokens, tagToken{value, !escaped && (value == "and" || value == "or" || value == "not")})
+		b.Reset()
+		tag, escaped = false, false
+	}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) || (runes[i+1] != '\\' && runes[i+1] != '(' && runes[i+1] != ')' && !unicode.IsSpace(runes[i+1])) {
+				return nil, fmt.Errorf("illegal escape before %s", escapedRune(runes, i+1))
+			}
+			i++
+			b.WriteRune(runes[i])
+			tag, escaped = true, true
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, tagToken{string(r), true})
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			b.WriteRune(r)
+			tag = true
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+func escapedRune(runes []rune, i int) string {
+	if i == len(runes) {
+		return "end of expression"
+	}
+	return fmt.Sprintf(`"%c"`, runes[i])
+}
+
+// tagParser is a recursive descent parser, not binds tighter than and which binds tighter than or
+type tagParser struct {
+	tokens   []tagToken
+	position int
+}
+
+func (p *tagParser) accept(operator string) bool {
+	if p.position < len(p.tokens) && p.tokens[p.position].operator && p.tokens[p.position].value == operator {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *tagParser) parseOr() (tagNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = tagOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagParser) parseAnd() (tagNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = tagAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagParser) parseNot() (tagNode, error) {
+	if p.accept("not") {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return tagNot{node}, nil
+	}
+	if p.accept("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("a closing parenthesis is missing")
+		}
+		return node, nil
+	}
+	if p.position == len(p.tokens) {
+		return nil, fmt.Errorf("a tag is expected at the end of the expression")
+	}
+	token := p.tokens[p.position]
+	if token.operator {
+		return nil, fmt.Errorf(`a tag is expected instead of "%s"`, token.value)
+	}
+	p.position++
+	return tagLiteral(token.value), nil
+}
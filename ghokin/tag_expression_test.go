@@ -0,0 +1,65 @@
+package ghokin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagExpression(t *testing.T) {
+	type scenario struct {
+		expression string
+		tags       []string
+		expected   bool
+	}
+
+	scenarios := []scenario{
+		{"", []string{}, true},
+		{"@smoke", []string{"@smoke"}, true},
+		{"@smoke", []string{"@wip"}, false},
+		{"@smoke and not @wip", []string{"@smoke"}, true},
+		{"@smoke and not @wip", []string{"@smoke", "@wip"}, false},
+		{"@a or @b and @c", []string{"@a"}, true},
+		{"(@a or @b) and @c", []string{"@a"}, false},
+		{"not not @a", []string{"@a"}, true},
+		{"not (@a or @b)", []string{"@c"}, true},
+		{`@a\ b or @c\(d\)`, []string{"@c(d)"}, true},
+		{`@a\ b`, []string{"@a b"}, true},
+		{`and\ `, []string{"and "}, true},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.expression, func(t *testing.T) {
+			expression, err := ParseTagExpression(s.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expression, expression.String())
+			assert.Equal(t, s.expected, expression.Match(s.tags))
+		})
+	}
+}
+
+func TestParseTagExpressionWithErrors(t *testing.T) {
+	type scenario struct {
+		expression string
+		err        string
+	}
+
+	scenarios := []scenario{
+		{"@a and", `tag expression "@a and" is invalid: a tag is expected at the end of the expression`},
+		{"@a @b", `tag expression "@a @b" is invalid: unexpected "@b"`},
+		{"(@a or @b", `tag expression "(@a or @b" is invalid: a closing parenthesis is missing`},
+		{"@a)", `tag expression "@a)" is invalid: unexpected ")"`},
+		{"or @a", `tag expression "or @a" is invalid: a tag is expected instead of "or"`},
+		{`@a\b`, `tag expression "@a\b" is invalid: illegal escape before "b"`},
+		{`@a\`, `tag expression "@a\" is invalid: illegal escape before end of expression`},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.expression, func(t *testing.T) {
+			_, err := ParseTagExpression(s.expression)
+			assert.EqualError(t, err, s.err)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package ghokin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v28"
+)
+
+// TagSort defines how tags of an element are ordered
+type TagSort int
+
+const (
+	// TagSortPreserve keeps tags in their original order
+	TagSortPreserve TagSort = iota
+	// TagSortAlphabetical sorts tags alphabetically
+	TagSortAlphabetical
+	// TagSortPriority puts tags of the priority list first in the list order,
+	// other tags follow sorted alphabetically
+	TagSortPriority
+)
+
+// String returns a human readable tag sort
+func (t TagSort) String() string {
+	switch t {
+	case TagSortAlphabetical:
+		return "alphabetical"
+	case TagSortPriority:
+		return "priority"
+	default:
+		return "preserve"
+	}
+}
+
+// ParseTagSort converts a sort name to a TagSort
+func ParseTagSort(sort string) (TagSort, error) {
+	for _, t := range []TagSort{TagSortPreserve, TagSortAlphabetical, TagSortPriority} {
+		if strings.EqualFold(t.String(), sort) {
+			return t, nil
+		}
+	}
+	return TagSortPreserve, fmt.Errorf(`tag sort "%s" doesn't exist, it must be one of preserve, alphabetical or priority`, sort)
+}
+
+// TagLines defines how tags are spread over lines
+type TagLines int
+
+const (
+	// TagLinesPreserve keeps tags on their original line
+	TagLinesPreserve TagLines = iota
+	// TagLinesMerge puts all tags of an element on a single line
+	TagLinesMerge
+	// TagLinesSplit puts every tag on its own line
+	TagLinesSplit
+)
+
+// String returns a human readable tag lines mode
+func (t TagLines) String() string {
+	switch t {
+	case TagLinesMerge:
+		return "merge"
+	case TagLinesSplit:
+		return "split"
+	default:
+		return "preserve"
+	}
+}
+
+// ParseTagLines converts a mode name to a TagLines
+func ParseTagLines(lines string) (TagLines, error) {
+	for _, t := range []TagLines{TagLinesPreserve, TagLinesMerge, TagLinesSplit} {
+		if strings.EqualFold(t.String(), lines) {
+			return t, nil
+		}
+	}
+	return TagLinesPreserve, fmt.Errorf(`tag lines "%s" doesn't exist, it must be one of preserve, merge or split`, lines)
+}
+
+// TagStyle defines how tag lines are rewritten when formatting, Width is the maximum
+// length of a tag line including its indentation, 0 means lines are never wrapped
+type TagStyle struct {
+	Sort     TagSort
+	Priority []string
+	Dedupe   bool
+	Lines    TagLines
+	Width    int
+}
+
+// format renders tag line tokens, padding is the indentation of lines
+func (t TagStyle) format(tokens []*gherkin.Token, padding int) []string {
+	lines := [][]string{}
+	seen := map[string]bool{}
+	for _, token := range tokens {
+		line := []string{}
+		for _, item := range token.Items {
+			if t.Dedupe && seen[item.Text] {
+				continue
+			}
+			seen[item.Text] = true
+			line = append(line, item.Text)
+		}
+		if len(line) > 0 || len(token.Items) == 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	switch t.Lines {
+	case TagLinesMerge:
+		lines = [][]string{t.sort(flattenTags(lines))}
+	case TagLinesSplit:
+		tags := t.sort(flattenTags(lines))
+		lines = [][]string{}
+		for _, tag := range tags {
+			lines = append(lines, []string{tag})
+		}
+	default:
+		for i, line := range lines {
+			lines[i] = t.sort(line)
+		}
+	}
+
+	content := []string{}
+	for _, line := range lines {
+		content = append(content, t.wrap(line, padding)...)
+	}
+	return content
+}
+
+func flattenTags(lines [][]string) []string {
+	tags := []string{}
+	for _, line := range lines {
+		tags = append(tags, line...)
+	}
+	return tags
+}
+
+func (t TagStyle) sort(tags []string) []string {
+	if t.Sort == TagSortPreserve {
+		return tags
+	}
+	priorities := map[string]int{}
+	if t.Sort == TagSortPriority {
+		for i, tag := range t.Priority {
+			tag = "@" + strings.TrimPrefix(tag, "@")
+			if _, ok := priorities[tag]; !ok {
+				priorities[tag] = i
+			}
+		}
+	}
+	sorted := append([]string{}, tags...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, iok := priorities[sorted[i]]
+		pj, jok := priorities[sorted[j]]
+		switch {
+		case iok && jok:
+			return pi < pj
+		case iok != jok:
+			return iok
+		default:
+			return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+		}
+	})
+	return sorted
+}
+
+// wrap splits tags on several lines when they exceed the width,
+// a tag longer than the width is put alone on its line
+func (t TagStyle) wrap(tags []string, padding int) []string {
+	if t.Width <= 0 {
+		return []string{strings.Join(tags, " ")}
+	}
+	lines := []string{}
+	line := ""
+	for _, tag := range tags {
+		if line != "" && padding+len([]rune(line))+1+len([]rune(tag)) > t.Width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += tag
+	}
+	return append(lines, line)
+}
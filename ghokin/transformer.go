@@ -9,6 +9,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"github.com/antham/ghokin/v3/ghokin/internal/transformer"
 	"github.com/cucumber/gherkin/go/v28"
 )
 
@@ -23,6 +24,47 @@ func (e CmdErr) Error() string {
 	return e.output
 }
 
+// IdempotencyErr is thrown when formatting an already formatted
+// content changes it again, the diff between both passes is stored
+type IdempotencyErr struct {
+	diff string
+}
+
+// Error outputs the diff between the first and the second formatting pass
+func (e IdempotencyErr) Error() string {
+	return "formatting is not idempotent, a second pass changes the content :\n" + e.diff
+}
+
+func format(content []byte, indent int, aliases aliases, opts options) ([]byte, error) {
+	first, err := formatOnce(content, indent, aliases, opts)
+	if err != nil || !opts.checkIdempotency {
+		return first, err
+	}
+	second, err := formatOnce(first, indent, aliases, opts)
+	if err != nil {
+		return []byte{}, err
+	}
+	if !bytes.Equal(first, second) {
+		return []byte{}, IdempotencyErr{unifiedDiff("first pass", "second pass", first, second)}
+	}
+	return first, nil
+}
+
+func formatOnce(content []byte, indent int, aliases aliases, opts options) ([]byte, error) {
+	contentTransformer := &transformer.ContentTransformer{}
+	contentTransformer.DetectSettings(content)
+	content = contentTransformer.Prepare(content)
+	section, err := extractSections(content)
+	if err != nil {
+		return []byte{}, err
+	}
+	content, err = transform(section, indent, aliases, opts)
+	if err != nil {
+		return []byte{}, err
+	}
+	return contentTransformer.Restore(content), nil
+}
+
 func extractSections(content []byte) (*section, error) {
 	section := &section{}
 	builder := &tokenGenerator{section: section}
@@ -33,7 +75,7 @@ func extractSections(content []byte) (*section, error) {
 	return section, parser.Parse(scanner, matcher)
 }
 
-func transform(section *section, indent int, aliases aliases) ([]byte, error) {
+func transform(section *section, indent int, aliases aliases, opts options) ([]byte, error) {
 	paddings := map[gherkin.TokenType]int{
 		gherkin.TokenTypeFeatureLine:        0,
 		gherkin.TokenTypeBackgroundLine:     indent,
@@ -65,6 +107,9 @@ func transform(section *section, indent int, aliases aliases) ([]byte, error) {
 	document := []string{}
 	optionalRulePadding := 0
 	accumulator := []*gherkin.Token{}
+	stepKeywordRewriter := &stepKeywordRewriter{style: opts.stepKeywordStyle, keywords: opts.keywords}
+	var previousElement gherkin.TokenType
+	hasLanguage := false
 
 	for sec := section; sec != nil; sec = sec.nex {
 		values := sec.values
@@ -88,43 +133,90 @@ func transform(section *section, indent int, aliases aliases) ([]byte, error) {
 		if sec.kind == 0 {
 			continue
 		}
+		if opts.language != "" {
+			values = translateTokens(values, opts.language, opts.keywords)
+		}
+		if len(opts.keywords) > 0 {
+			values = canonicalizeTokens(values, opts.keywords)
+		}
+		if sec.kind == gherkin.TokenTypeLanguage {
+			hasLanguage = true
+		}
 		padding := paddings[sec.kind] + optionalRulePadding
 		lines := formats[sec.kind](values)
 		switch sec.kind {
 		case gherkin.TokenTypeRuleLine:
 			optionalRulePadding = indent
 			padding = indent
+			stepKeywordRewriter.reset()
+		case gherkin.TokenTypeFeatureLine, gherkin.TokenTypeBackgroundLine, gherkin.TokenTypeScenarioLine:
+			stepKeywordRewriter.reset()
+		case gherkin.TokenTypeStepLine:
+			if opts.stepKeywordStyle != StepKeywordPreserve {
+				lines = formats[sec.kind](stepKeywordRewriter.rewrite(values))
+			}
 		case gherkin.TokenTypeComment, gherkin.TokenTypeLanguage:
 			cmd = extractCommand(sec.values, aliases)
-			padding = getTagOrCommentPadding(paddings, indent, sec)
+			padding = getTagOrCommentPadding(paddings, indent, optionalRulePadding, sec)
 			lines = trimLinesSpace(lines)
 		case gherkin.TokenTypeTagLine:
-			padding = getTagOrCommentPadding(paddings, indent, sec)
+			padding = getTagOrCommentPadding(paddings, indent, optionalRulePadding, sec)
+			lines = opts.tagStyle.format(values, padding)
 		case gherkin.TokenTypeDocStringSeparator:
 			lines = extractKeyword(sec.values)
 		case gherkin.TokenTypeOther:
+			description := lines
 			if isDescriptionFeature(sec) {
 				lines = trimLinesSpace(lines)
 				padding = indent
 			} else if isDescriptionScenario(sec) {
 				lines = trimLinesSpace(lines)
 				padding = paddings[gherkin.TokenTypeScenarioLine] + optionalRulePadding
+			} else if isDescriptionRule(sec) {
+				lines = trimLinesSpace(lines)
+				padding = indent
+			} else {
+				break
+			}
+			if opts.maxLineLength > 0 {
+				lines = reflowDescription(description, opts.maxLineLength-padding)
 			}
 		}
 
-		computed, lines, err := computeCommand(cmd, lines, sec)
+		computed, lines, err := computeCommand(cmd, lines, sec, opts.aliasLimiter)
 		if err != nil {
 			return []byte{}, err
 		}
 		if computed {
 			cmd = nil
 		}
+		if opts.blankLines != nil {
+			if count := opts.blankLines.before(sec, previousElement); count >= 0 {
+				document = setBlankLines(document, count)
+			}
+			switch {
+			case sec.kind == gherkin.TokenTypeEmpty:
+				lines = opts.blankLines.emptyLines(document, lines)
+			case sec.kind == gherkin.TokenTypeOther && (sec.prev == nil || sec.prev.kind != gherkin.TokenTypeDocStringSeparator):
+				lines = opts.blankLines.collapseLines(lines)
+			}
+		}
+		switch sec.kind {
+		case gherkin.TokenTypeFeatureLine, gherkin.TokenTypeBackgroundLine, gherkin.TokenTypeScenarioLine, gherkin.TokenTypeRuleLine:
+			previousElement = sec.kind
+		}
 		document = append(document, trimExtraTrailingSpace(indentStrings(padding, lines))...)
 	}
+	if opts.blankLines != nil && opts.blankLines.Trim {
+		document = trimBlankLines(document)
+	}
+	if !hasLanguage && opts.language != "" && opts.language != gherkin.DefaultDialect {
+		document = append([]string{fmt.Sprintf("# language: %s", opts.language)}, document...)
+	}
 	return []byte(strings.Join(document, "\n") + "\n"), nil
 }
 
-func getTagOrCommentPadding(paddings map[gherkin.TokenType]int, indent int, sec *section) int {
+func getTagOrCommentPadding(paddings map[gherkin.TokenType]int, indent int, rulePadding int, sec *section) int {
 	var kind gherkin.TokenType
 	excluded := []gherkin.TokenType{gherkin.TokenTypeTagLine, gherkin.TokenTypeComment}
 	if sec.next(excluded) != nil {
@@ -141,13 +233,20 @@ func getTagOrCommentPadding(paddings map[gherkin.TokenType]int, indent int, sec
 	if sec.next([]gherkin.TokenType{gherkin.TokenTypeEmpty}) == nil {
 		return indent
 	}
-	return paddings[kind]
+	if kind == gherkin.TokenTypeRuleLine {
+		return indent
+	}
+	return paddings[kind] + rulePadding
 }
 
-func computeCommand(cmd *exec.Cmd, lines []string, sec *section) (bool, []string, error) {
+func computeCommand(cmd *exec.Cmd, lines []string, sec *section, limiter chan struct{}) (bool, []string, error) {
 	if sec.kind == gherkin.TokenTypeComment || sec.kind == gherkin.TokenTypeDocStringSeparator || cmd == nil {
 		return false, lines, nil
 	}
+	if limiter != nil {
+		limiter <- struct{}{}
+		defer func() { <-limiter }()
+	}
 	l, err := runCommand(cmd, lines)
 	if err != nil {
 		return true, []string{}, err
@@ -165,6 +264,16 @@ func isDescriptionFeature(sec *section) bool {
 	return false
 }
 
+func isDescriptionRule(sec *section) bool {
+	excluded := []gherkin.TokenType{gherkin.TokenTypeEmpty}
+	if sec.previous(excluded) != nil {
+		if s := sec.previous(excluded); s != nil && s.kind == gherkin.TokenTypeRuleLine {
+			return true
+		}
+	}
+	return false
+}
+
 func isDescriptionScenario(sec *section) bool {
 	excluded := []gherkin.TokenType{gherkin.TokenTypeEmpty}
 	if sec.previous(excluded) != nil {
@@ -3,6 +3,7 @@ package ghokin
 import (
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
 	gherkin "github.com/cucumber/gherkin/go/v28"
@@ -447,6 +448,14 @@ func TestTransform(t *testing.T) {
 			"fixtures/rule.feature",
 			"fixtures/rule.feature",
 		},
+		{
+			"fixtures/rule-with-tags.input.feature",
+			"fixtures/rule-with-tags.expected.feature",
+		},
+		{
+			"fixtures/rule-description.input.feature",
+			"fixtures/rule-description.expected.feature",
+		},
 		{
 			"fixtures/non-ascii-characters-formatting.feature",
 			"fixtures/non-ascii-characters-formatting.feature",
@@ -482,7 +491,7 @@ func TestTransform(t *testing.T) {
 				"seq": "seq 1 3",
 			}
 
-			buf, err := transform(s, 2, aliases)
+			buf, err := transform(s, 2, aliases, options{})
 			assert.NoError(t, err)
 
 			b, e := os.ReadFile(scenario.expected)
@@ -491,3 +500,454 @@ func TestTransform(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatWithIdempotencyCheck(t *testing.T) {
+	type scenario struct {
+		name    string
+		content string
+		test    func([]byte, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Format an idempotent content",
+			"Feature: test\nScenario: scenario\nGiven a thing\n",
+			func(buf []byte, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "Feature: test\n  Scenario: scenario\n    Given a thing\n", string(buf))
+			},
+		},
+		{
+			"Format a content changing on a second pass",
+			"Feature: test\n  Scenario: scenario\n    # @bang\n    Given a thing\n",
+			func(buf []byte, err error) {
+				assert.EqualError(t, err, `formatting is not idempotent, a second pass changes the content :
+--- first pass
++++ second pass
+@@ -1,4 +1,4 @@
+ Feature: test
+   Scenario: scenario
+     # @bang
+-    Given a thing!
++    Given a thing!!
+`)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			aliases := map[string]string{
+				"bang": "sed 's/$/!/'",
+			}
+			scenario.test(format([]byte(scenario.content), 2, aliases, newOptions([]Option{WithIdempotencyCheck(true)})))
+		})
+	}
+}
+
+func TestFormatWithStepKeywordStyle(t *testing.T) {
+	type scenario struct {
+		name    string
+		style   StepKeywordStyle
+		content string
+		output  string
+	}
+
+	content := `Feature: test
+  Background:
+    Given a thing
+    Given another thing
+
+  Scenario: scenario
+    Given a thing
+    Given another thing
+    # a comment
+    Given a third thing
+    When an action
+    And another action
+    Then an outcome
+    But not another outcome
+    * a star step
+    Then a last outcome
+`
+
+	scenarios := []scenario{
+		{
+			"Preserve step keywords",
+			StepKeywordPreserve,
+			content,
+			content,
+		},
+		{
+			"Rewrite repeated step keywords with And",
+			StepKeywordConjunction,
+			content,
+			`Feature: test
+  Background:
+    Given a thing
+    And another thing
+
+  Scenario: scenario
+    Given a thing
+    And another thing
+    # a comment
+    And a third thing
+    When an action
+    And another action
+    Then an outcome
+    But not another outcome
+    * a star step
+    And a last outcome
+`,
+		},
+		{
+			"Rewrite And and But with explicit keywords",
+			StepKeywordExplicit,
+			content,
+			`Feature: test
+  Background:
+    Given a thing
+    Given another thing
+
+  Scenario: scenario
+    Given a thing
+    Given another thing
+    # a comment
+    Given a third thing
+    When an action
+    When another action
+    Then an outcome
+    Then not another outcome
+    * a star step
+    Then a last outcome
+`,
+		},
+		{
+			"Rewrite repeated step keywords with the dialect of the file",
+			StepKeywordConjunction,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Soit une chose
+    Soit une autre chose
+    Quand une action
+    Alors un résultat
+    Alors un autre résultat
+`,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Soit une chose
+    Et que une autre chose
+    Quand une action
+    Alors un résultat
+    Et que un autre résultat
+`,
+		},
+		{
+			"Rewrite And with explicit keywords from the dialect of the file",
+			StepKeywordExplicit,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Et une chose
+    Quand une action
+    Et une autre action
+`,
+			`# language: fr
+Fonctionnalité: test
+  Scénario: scénario
+    Et une chose
+    Quand une action
+    Quand une autre action
+`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			buf, err := format([]byte(scenario.content), 2, map[string]string{}, newOptions([]Option{WithStepKeywordStyle(scenario.style), WithIdempotencyCheck(true)}))
+			assert.NoError(t, err)
+			assert.EqualValues(t, scenario.output, string(buf))
+		})
+	}
+}
+
+func TestParseStepKeywordStyle(t *testing.T) {
+	for _, style := range []StepKeywordStyle{StepKeywordPreserve, StepKeywordConjunction, StepKeywordExplicit} {
+		s, err := ParseStepKeywordStyle(strings.ToUpper(style.String()))
+		assert.NoError(t, err)
+		assert.Equal(t, style, s)
+	}
+
+	_, err := ParseStepKeywordStyle("whatever")
+	assert.EqualError(t, err, `step keyword style "whatever" doesn't exist, it must be one of preserve, and or explicit`)
+}
+
+func TestFormatWithTagStyle(t *testing.T) {
+	type scenario struct {
+		name   string
+		style  TagStyle
+		output string
+	}
+
+	content := `@feature
+Feature: test
+  @wip @smoke @Billing @wip
+  @api @smoke
+  Scenario: scenario
+    Given a thing
+`
+
+	scenarios := []scenario{
+		{
+			"Preserve tags",
+			TagStyle{},
+			content,
+		},
+		{
+			"Sort tags alphabetically on each line",
+			TagStyle{Sort: TagSortAlphabetical},
+			`@feature
+Feature: test
+  @Billing @smoke @wip @wip
+  @api @smoke
+  Scenario: scenario
+    Given a thing
+`,
+		},
+		{
+			"Remove duplicated tags",
+			TagStyle{Dedupe: true},
+			`@feature
+Feature: test
+  @wip @smoke @Billing
+  @api
+  Scenario: scenario
+    Given a thing
+`,
+		},
+		{
+			"Merge tags sorted by priority",
+			TagStyle{Sort: TagSortPriority, Priority: []string{"wip", "@smoke"}, Dedupe: true, Lines: TagLinesMerge},
+			`@feature
+Feature: test
+  @wip @smoke @api @Billing
+  Scenario: scenario
+    Given a thing
+`,
+		},
+		{
+			"Split tags",
+			TagStyle{Sort: TagSortAlphabetical, Dedupe: true, Lines: TagLinesSplit},
+			`@feature
+Feature: test
+  @api
+  @Billing
+  @smoke
+  @wip
+  Scenario: scenario
+    Given a thing
+`,
+		},
+		{
+			"Wrap merged tags",
+			TagStyle{Lines: TagLinesMerge, Width: 16},
+			`@feature
+Feature: test
+  @wip @smoke
+  @Billing @wip
+  @api @smoke
+  Scenario: scenario
+    Given a thing
+`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			buf, err := format([]byte(content), 2, map[string]string{}, newOptions([]Option{WithTagStyle(scenario.style), WithIdempotencyCheck(true)}))
+			assert.NoError(t, err)
+			assert.EqualValues(t, scenario.output, string(buf))
+		})
+	}
+}
+
+func TestParseTagSortAndTagLines(t *testing.T) {
+	for _, sort := range []TagSort{TagSortPreserve, TagSortAlphabetical, TagSortPriority} {
+		s, err := ParseTagSort(strings.ToUpper(sort.String()))
+		assert.NoError(t, err)
+		assert.Equal(t, sort, s)
+	}
+	_, err := ParseTagSort("whatever")
+	assert.EqualError(t, err, `tag sort "whatever" doesn't exist, it must be one of preserve, alphabetical or priority`)
+
+	for _, lines := range []TagLines{TagLinesPreserve, TagLinesMerge, TagLinesSplit} {
+		l, err := ParseTagLines(strings.ToUpper(lines.String()))
+		assert.NoError(t, err)
+		assert.Equal(t, lines, l)
+	}
+	_, err = ParseTagLines("whatever")
+	assert.EqualError(t, err, `tag lines "whatever" doesn't exist, it must be one of preserve, merge or split`)
+}
+
+func TestFormatWithBlankLines(t *testing.T) {
+	type scenario struct {
+		name   string
+		policy BlankLines
+		output string
+	}
+
+	content := `
+
+Feature: test
+  A description
+
+
+
+  with paragraphs
+  Background:
+    Given a thing
+  @tag
+  Scenario: scenario 1
+    Given a thing
+
+
+  # a comment
+  Scenario Outline: scenario 2
+    Given a <thing>
+    Examples:
+      | thing |
+      | book  |
+  Rule: a rule
+
+    Scenario: scenario 3
+      Given a thing
+        """
+        a
+
+
+        b
+        """
+
+
+`
+
+	scenarios := []scenario{
+		{
+			"Preserve empty lines",
+			BlankLines{BlankLinesPreserve, BlankLinesPreserve, BlankLinesPreserve, BlankLinesPreserve, false, false},
+			content,
+		},
+		{
+			"Collapse and trim empty lines",
+			BlankLines{BlankLinesPreserve, BlankLinesPreserve, BlankLinesPreserve, BlankLinesPreserve, true, true},
+			`Feature: test
+  A description
+
+  with paragraphs
+  Background:
+    Given a thing
+  @tag
+  Scenario: scenario 1
+    Given a thing
+
+  # a comment
+  Scenario Outline: scenario 2
+    Given a <thing>
+    Examples:
+      | thing |
+      | book  |
+  Rule: a rule
+
+    Scenario: scenario 3
+      Given a thing
+        """
+        a
+
+
+        b
+        """
+`,
+		},
+		{
+			"Set empty lines between elements",
+			BlankLines{1, 2, 1, 1, true, true},
+			`Feature: test
+
+  A description
+
+  with paragraphs
+  Background:
+    Given a thing
+
+
+  @tag
+  Scenario: scenario 1
+    Given a thing
+
+  # a comment
+  Scenario Outline: scenario 2
+    Given a <thing>
+
+    Examples:
+      | thing |
+      | book  |
+
+  Rule: a rule
+
+    Scenario: scenario 3
+      Given a thing
+        """
+        a
+
+
+        b
+        """
+`,
+		},
+		{
+			"Remove empty lines between elements",
+			BlankLines{0, 0, 0, 0, false, false},
+			`
+
+Feature: test
+  A description
+
+
+
+  with paragraphs
+  Background:
+    Given a thing
+  @tag
+  Scenario: scenario 1
+    Given a thing
+  # a comment
+  Scenario Outline: scenario 2
+    Given a <thing>
+    Examples:
+      | thing |
+      | book  |
+  Rule: a rule
+    Scenario: scenario 3
+      Given a thing
+        """
+        a
+
+
+        b
+        """
+
+
+`,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			buf, err := format([]byte(content), 2, map[string]string{}, newOptions([]Option{WithBlankLines(scenario.policy), WithIdempotencyCheck(true)}))
+			assert.NoError(t, err)
+			assert.EqualValues(t, scenario.output, string(buf))
+		})
+	}
+}